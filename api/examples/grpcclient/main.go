@@ -0,0 +1,62 @@
+// Command grpcclient lists the players of a running
+// `bluesoundplayer daemon --grpc 127.0.0.1:50051` and prints their status,
+// or streams their events with -watch.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "bluesoundplayer/api/v1"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:50051", "daemon gRPC address")
+	watch := flag.Bool("watch", false, "stream player events")
+	flag.Parse()
+
+	// The daemon speaks plain-text HTTP/2
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewPlayerClient(conn)
+
+	if *watch {
+		stream, err := client.Watch(context.Background(), &pb.WatchRequest{Players: flag.Args()})
+		if err != nil {
+			log.Fatal(err)
+		}
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s %-8s %s %s\n", time.UnixMilli(event.GetTimeUnixMs()).Format(time.TimeOnly),
+				event.GetType(), event.GetPlayer(), event.GetStatus().GetState())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	list, err := client.ListPlayers(ctx, &pb.Empty{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, player := range list.GetPlayers() {
+		status, err := client.GetStatus(ctx, &pb.PlayerRequest{Player: player.GetIp()})
+		if err != nil {
+			fmt.Printf("%-20s %s\n", player.GetName(), err)
+			continue
+		}
+		fmt.Printf("%-20s %-8s %s - %s (volume %d)\n", player.GetName(), status.GetState(),
+			status.GetArtist(), status.GetSong(), status.GetVolume())
+	}
+}
@@ -0,0 +1,1614 @@
+// gRPC control API of `bluesoundplayer daemon --grpc 127.0.0.1:50051`.
+//
+// The generated Go client lives in the bluesoundplayer/api module (api/v1).
+// Regenerate it from the repository root with:
+//
+//   protoc -I proto \
+//     --go_out=api --go_opt=module=bluesoundplayer/api \
+//     --go-grpc_out=api --go-grpc_opt=module=bluesoundplayer/api \
+//     bluesoundplayer/v1/player.proto
+//
+// Players are addressed by name (case-insensitive) or IP address.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: bluesoundplayer/v1/player.proto
+
+package bluesoundplayerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{0}
+}
+
+type PlayerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Brand         string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"` // "bluos" or "sonos"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerInfo) Reset() {
+	*x = PlayerInfo{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerInfo) ProtoMessage() {}
+
+func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
+func (*PlayerInfo) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type PlayerList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Players       []*PlayerInfo          `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerList) Reset() {
+	*x = PlayerList{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerList) ProtoMessage() {}
+
+func (x *PlayerList) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerList.ProtoReflect.Descriptor instead.
+func (*PlayerList) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlayerList) GetPlayers() []*PlayerInfo {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type PlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerRequest) Reset() {
+	*x = PlayerRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerRequest) ProtoMessage() {}
+
+func (x *PlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerRequest.ProtoReflect.Descriptor instead.
+func (*PlayerRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlayerRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+type Status struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // as reported by the player, e.g. "play" or "paused_playback"
+	Song          string                 `protobuf:"bytes,2,opt,name=song,proto3" json:"song,omitempty"`
+	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album         string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	Volume        int32                  `protobuf:"varint,5,opt,name=volume,proto3" json:"volume,omitempty"`
+	StreamUrl     string                 `protobuf:"bytes,6,opt,name=stream_url,json=streamUrl,proto3" json:"stream_url,omitempty"`
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Status) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Status) GetSong() string {
+	if x != nil {
+		return x.Song
+	}
+	return ""
+}
+
+func (x *Status) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *Status) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *Status) GetVolume() int32 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Status) GetStreamUrl() string {
+	if x != nil {
+		return x.StreamUrl
+	}
+	return ""
+}
+
+func (x *Status) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type Preset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preset) Reset() {
+	*x = Preset{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preset) ProtoMessage() {}
+
+func (x *Preset) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preset.ProtoReflect.Descriptor instead.
+func (*Preset) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Preset) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Preset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Preset) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Preset) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type PresetList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Presets       []*Preset              `protobuf:"bytes,1,rep,name=presets,proto3" json:"presets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresetList) Reset() {
+	*x = PresetList{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresetList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresetList) ProtoMessage() {}
+
+func (x *PresetList) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresetList.ProtoReflect.Descriptor instead.
+func (*PresetList) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PresetList) GetPresets() []*Preset {
+	if x != nil {
+		return x.Presets
+	}
+	return nil
+}
+
+type PlayPresetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayPresetRequest) Reset() {
+	*x = PlayPresetRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayPresetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayPresetRequest) ProtoMessage() {}
+
+func (x *PlayPresetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayPresetRequest.ProtoReflect.Descriptor instead.
+func (*PlayPresetRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PlayPresetRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *PlayPresetRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PlayURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayURLRequest) Reset() {
+	*x = PlayURLRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayURLRequest) ProtoMessage() {}
+
+func (x *PlayURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayURLRequest.ProtoReflect.Descriptor instead.
+func (*PlayURLRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlayURLRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *PlayURLRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PlayURLRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type SetVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Level         int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"` // 0-100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVolumeRequest) Reset() {
+	*x = SetVolumeRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVolumeRequest) ProtoMessage() {}
+
+func (x *SetVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVolumeRequest.ProtoReflect.Descriptor instead.
+func (*SetVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetVolumeRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *SetVolumeRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type GroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Slaves        []string               `protobuf:"bytes,2,rep,name=slaves,proto3" json:"slaves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupRequest) Reset() {
+	*x = GroupRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRequest) ProtoMessage() {}
+
+func (x *GroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRequest.ProtoReflect.Descriptor instead.
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GroupRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *GroupRequest) GetSlaves() []string {
+	if x != nil {
+		return x.Slaves
+	}
+	return nil
+}
+
+type QueueItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist        string                 `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album         string                 `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	Uri           string                 `protobuf:"bytes,5,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueItem) Reset() {
+	*x = QueueItem{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueItem) ProtoMessage() {}
+
+func (x *QueueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueItem.ProtoReflect.Descriptor instead.
+func (*QueueItem) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueueItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *QueueItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *QueueItem) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *QueueItem) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *QueueItem) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type Queue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*QueueItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Queue) Reset() {
+	*x = Queue{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Queue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Queue) ProtoMessage() {}
+
+func (x *Queue) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Queue.ProtoReflect.Descriptor instead.
+func (*Queue) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Queue) GetItems() []*QueueItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type AddToQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Next          bool                   `protobuf:"varint,4,opt,name=next,proto3" json:"next,omitempty"` // play next instead of appending
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToQueueRequest) Reset() {
+	*x = AddToQueueRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToQueueRequest) ProtoMessage() {}
+
+func (x *AddToQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToQueueRequest.ProtoReflect.Descriptor instead.
+func (*AddToQueueRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddToQueueRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *AddToQueueRequest) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *AddToQueueRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddToQueueRequest) GetNext() bool {
+	if x != nil {
+		return x.Next
+	}
+	return false
+}
+
+type QueueIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueIndexRequest) Reset() {
+	*x = QueueIndexRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueIndexRequest) ProtoMessage() {}
+
+func (x *QueueIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueIndexRequest.ProtoReflect.Descriptor instead.
+func (*QueueIndexRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueueIndexRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *QueueIndexRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type MoveQueueItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	From          int32                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveQueueItemRequest) Reset() {
+	*x = MoveQueueItemRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveQueueItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveQueueItemRequest) ProtoMessage() {}
+
+func (x *MoveQueueItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveQueueItemRequest.ProtoReflect.Descriptor instead.
+func (*MoveQueueItemRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MoveQueueItemRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *MoveQueueItemRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *MoveQueueItemRequest) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type SaveQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveQueueRequest) Reset() {
+	*x = SaveQueueRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveQueueRequest) ProtoMessage() {}
+
+func (x *SaveQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveQueueRequest.ProtoReflect.Descriptor instead.
+func (*SaveQueueRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SaveQueueRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *SaveQueueRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ScenePlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        string                 `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Preset        int32                  `protobuf:"varint,2,opt,name=preset,proto3" json:"preset,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Volume        *int32                 `protobuf:"varint,5,opt,name=volume,proto3,oneof" json:"volume,omitempty"`
+	Stop          bool                   `protobuf:"varint,6,opt,name=stop,proto3" json:"stop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScenePlayer) Reset() {
+	*x = ScenePlayer{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScenePlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenePlayer) ProtoMessage() {}
+
+func (x *ScenePlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenePlayer.ProtoReflect.Descriptor instead.
+func (*ScenePlayer) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScenePlayer) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *ScenePlayer) GetPreset() int32 {
+	if x != nil {
+		return x.Preset
+	}
+	return 0
+}
+
+func (x *ScenePlayer) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ScenePlayer) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ScenePlayer) GetVolume() int32 {
+	if x != nil && x.Volume != nil {
+		return *x.Volume
+	}
+	return 0
+}
+
+func (x *ScenePlayer) GetStop() bool {
+	if x != nil {
+		return x.Stop
+	}
+	return false
+}
+
+type Scene struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Group         []string               `protobuf:"bytes,2,rep,name=group,proto3" json:"group,omitempty"`
+	Players       []*ScenePlayer         `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scene) Reset() {
+	*x = Scene{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scene) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scene) ProtoMessage() {}
+
+func (x *Scene) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scene.ProtoReflect.Descriptor instead.
+func (*Scene) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Scene) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Scene) GetGroup() []string {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *Scene) GetPlayers() []*ScenePlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type SceneList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scenes        []*Scene               `protobuf:"bytes,1,rep,name=scenes,proto3" json:"scenes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SceneList) Reset() {
+	*x = SceneList{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SceneList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SceneList) ProtoMessage() {}
+
+func (x *SceneList) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SceneList.ProtoReflect.Descriptor instead.
+func (*SceneList) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SceneList) GetScenes() []*Scene {
+	if x != nil {
+		return x.Scenes
+	}
+	return nil
+}
+
+type ApplySceneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplySceneRequest) Reset() {
+	*x = ApplySceneRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplySceneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplySceneRequest) ProtoMessage() {}
+
+func (x *ApplySceneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplySceneRequest.ProtoReflect.Descriptor instead.
+func (*ApplySceneRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApplySceneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Players       []string               `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"` // names or IPs, all players if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchRequest) GetPlayers() []string {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type PlayerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "status", "track", "volume", "topology", "players", "offline" or "online"
+	Player        string                 `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Status        *Status                `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TimeUnixMs    int64                  `protobuf:"varint,5,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerEvent) Reset() {
+	*x = PlayerEvent{}
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerEvent) ProtoMessage() {}
+
+func (x *PlayerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_bluesoundplayer_v1_player_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerEvent.ProtoReflect.Descriptor instead.
+func (*PlayerEvent) Descriptor() ([]byte, []int) {
+	return file_bluesoundplayer_v1_player_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlayerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PlayerEvent) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PlayerEvent) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+var File_bluesoundplayer_v1_player_proto protoreflect.FileDescriptor
+
+const file_bluesoundplayer_v1_player_proto_rawDesc = "" +
+	"\n" +
+	"\x1fbluesoundplayer/v1/player.proto\x12\x12bluesoundplayer.v1\"\a\n" +
+	"\x05Empty\"p\n" +
+	"\n" +
+	"PlayerInfo\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\"F\n" +
+	"\n" +
+	"PlayerList\x128\n" +
+	"\aplayers\x18\x01 \x03(\v2\x1e.bluesoundplayer.v1.PlayerInfoR\aplayers\"'\n" +
+	"\rPlayerRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\"\xad\x01\n" +
+	"\x06Status\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x12\n" +
+	"\x04song\x18\x02 \x01(\tR\x04song\x12\x16\n" +
+	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05album\x18\x04 \x01(\tR\x05album\x12\x16\n" +
+	"\x06volume\x18\x05 \x01(\x05R\x06volume\x12\x1d\n" +
+	"\n" +
+	"stream_url\x18\x06 \x01(\tR\tstreamUrl\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\"T\n" +
+	"\x06Preset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\"B\n" +
+	"\n" +
+	"PresetList\x124\n" +
+	"\apresets\x18\x01 \x03(\v2\x1a.bluesoundplayer.v1.PresetR\apresets\";\n" +
+	"\x11PlayPresetRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"P\n" +
+	"\x0ePlayURLRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"@\n" +
+	"\x10SetVolumeRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\">\n" +
+	"\fGroupRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x16\n" +
+	"\x06slaves\x18\x02 \x03(\tR\x06slaves\"w\n" +
+	"\tQueueItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06artist\x18\x03 \x01(\tR\x06artist\x12\x14\n" +
+	"\x05album\x18\x04 \x01(\tR\x05album\x12\x10\n" +
+	"\x03uri\x18\x05 \x01(\tR\x03uri\"<\n" +
+	"\x05Queue\x123\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.bluesoundplayer.v1.QueueItemR\x05items\"g\n" +
+	"\x11AddToQueueRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04next\x18\x04 \x01(\bR\x04next\"A\n" +
+	"\x11QueueIndexRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"R\n" +
+	"\x14MoveQueueItemRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\">\n" +
+	"\x10SaveQueueRequest\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xa1\x01\n" +
+	"\vScenePlayer\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\tR\x06player\x12\x16\n" +
+	"\x06preset\x18\x02 \x01(\x05R\x06preset\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1b\n" +
+	"\x06volume\x18\x05 \x01(\x05H\x00R\x06volume\x88\x01\x01\x12\x12\n" +
+	"\x04stop\x18\x06 \x01(\bR\x04stopB\t\n" +
+	"\a_volume\"l\n" +
+	"\x05Scene\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05group\x18\x02 \x03(\tR\x05group\x129\n" +
+	"\aplayers\x18\x03 \x03(\v2\x1f.bluesoundplayer.v1.ScenePlayerR\aplayers\">\n" +
+	"\tSceneList\x121\n" +
+	"\x06scenes\x18\x01 \x03(\v2\x19.bluesoundplayer.v1.SceneR\x06scenes\"'\n" +
+	"\x11ApplySceneRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"(\n" +
+	"\fWatchRequest\x12\x18\n" +
+	"\aplayers\x18\x01 \x03(\tR\aplayers\"\x9f\x01\n" +
+	"\vPlayerEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06player\x18\x02 \x01(\tR\x06player\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x122\n" +
+	"\x06status\x18\x04 \x01(\v2\x1a.bluesoundplayer.v1.StatusR\x06status\x12 \n" +
+	"\ftime_unix_ms\x18\x05 \x01(\x03R\n" +
+	"timeUnixMs2\x83\x0f\n" +
+	"\x06Player\x12H\n" +
+	"\vListPlayers\x12\x19.bluesoundplayer.v1.Empty\x1a\x1e.bluesoundplayer.v1.PlayerList\x12C\n" +
+	"\x06Rescan\x12\x19.bluesoundplayer.v1.Empty\x1a\x1e.bluesoundplayer.v1.PlayerList\x12J\n" +
+	"\tGetStatus\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12O\n" +
+	"\n" +
+	"GetPresets\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1e.bluesoundplayer.v1.PresetList\x12O\n" +
+	"\n" +
+	"PlayPreset\x12%.bluesoundplayer.v1.PlayPresetRequest\x1a\x1a.bluesoundplayer.v1.Status\x12I\n" +
+	"\aPlayURL\x12\".bluesoundplayer.v1.PlayURLRequest\x1a\x1a.bluesoundplayer.v1.Status\x12E\n" +
+	"\x04Play\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12F\n" +
+	"\x05Pause\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12E\n" +
+	"\x04Stop\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12E\n" +
+	"\x04Next\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12I\n" +
+	"\bPrevious\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12M\n" +
+	"\tSetVolume\x12$.bluesoundplayer.v1.SetVolumeRequest\x1a\x1a.bluesoundplayer.v1.Status\x12I\n" +
+	"\tAddSlaves\x12 .bluesoundplayer.v1.GroupRequest\x1a\x1a.bluesoundplayer.v1.Status\x12L\n" +
+	"\fRemoveSlaves\x12 .bluesoundplayer.v1.GroupRequest\x1a\x1a.bluesoundplayer.v1.Status\x12H\n" +
+	"\aUngroup\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x1a.bluesoundplayer.v1.Status\x12H\n" +
+	"\bGetQueue\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x19.bluesoundplayer.v1.Queue\x12N\n" +
+	"\n" +
+	"AddToQueue\x12%.bluesoundplayer.v1.AddToQueueRequest\x1a\x19.bluesoundplayer.v1.Queue\x12S\n" +
+	"\x0fRemoveFromQueue\x12%.bluesoundplayer.v1.QueueIndexRequest\x1a\x19.bluesoundplayer.v1.Queue\x12T\n" +
+	"\rMoveQueueItem\x12(.bluesoundplayer.v1.MoveQueueItemRequest\x1a\x19.bluesoundplayer.v1.Queue\x12J\n" +
+	"\n" +
+	"ClearQueue\x12!.bluesoundplayer.v1.PlayerRequest\x1a\x19.bluesoundplayer.v1.Queue\x12R\n" +
+	"\rPlayQueueItem\x12%.bluesoundplayer.v1.QueueIndexRequest\x1a\x1a.bluesoundplayer.v1.Status\x12L\n" +
+	"\tSaveQueue\x12$.bluesoundplayer.v1.SaveQueueRequest\x1a\x19.bluesoundplayer.v1.Empty\x12F\n" +
+	"\n" +
+	"ListScenes\x12\x19.bluesoundplayer.v1.Empty\x1a\x1d.bluesoundplayer.v1.SceneList\x12N\n" +
+	"\n" +
+	"ApplyScene\x12%.bluesoundplayer.v1.ApplySceneRequest\x1a\x19.bluesoundplayer.v1.Empty\x12L\n" +
+	"\x05Watch\x12 .bluesoundplayer.v1.WatchRequest\x1a\x1f.bluesoundplayer.v1.PlayerEvent0\x01B*Z(bluesoundplayer/api/v1;bluesoundplayerv1b\x06proto3"
+
+var (
+	file_bluesoundplayer_v1_player_proto_rawDescOnce sync.Once
+	file_bluesoundplayer_v1_player_proto_rawDescData []byte
+)
+
+func file_bluesoundplayer_v1_player_proto_rawDescGZIP() []byte {
+	file_bluesoundplayer_v1_player_proto_rawDescOnce.Do(func() {
+		file_bluesoundplayer_v1_player_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bluesoundplayer_v1_player_proto_rawDesc), len(file_bluesoundplayer_v1_player_proto_rawDesc)))
+	})
+	return file_bluesoundplayer_v1_player_proto_rawDescData
+}
+
+var file_bluesoundplayer_v1_player_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_bluesoundplayer_v1_player_proto_goTypes = []any{
+	(*Empty)(nil),                // 0: bluesoundplayer.v1.Empty
+	(*PlayerInfo)(nil),           // 1: bluesoundplayer.v1.PlayerInfo
+	(*PlayerList)(nil),           // 2: bluesoundplayer.v1.PlayerList
+	(*PlayerRequest)(nil),        // 3: bluesoundplayer.v1.PlayerRequest
+	(*Status)(nil),               // 4: bluesoundplayer.v1.Status
+	(*Preset)(nil),               // 5: bluesoundplayer.v1.Preset
+	(*PresetList)(nil),           // 6: bluesoundplayer.v1.PresetList
+	(*PlayPresetRequest)(nil),    // 7: bluesoundplayer.v1.PlayPresetRequest
+	(*PlayURLRequest)(nil),       // 8: bluesoundplayer.v1.PlayURLRequest
+	(*SetVolumeRequest)(nil),     // 9: bluesoundplayer.v1.SetVolumeRequest
+	(*GroupRequest)(nil),         // 10: bluesoundplayer.v1.GroupRequest
+	(*QueueItem)(nil),            // 11: bluesoundplayer.v1.QueueItem
+	(*Queue)(nil),                // 12: bluesoundplayer.v1.Queue
+	(*AddToQueueRequest)(nil),    // 13: bluesoundplayer.v1.AddToQueueRequest
+	(*QueueIndexRequest)(nil),    // 14: bluesoundplayer.v1.QueueIndexRequest
+	(*MoveQueueItemRequest)(nil), // 15: bluesoundplayer.v1.MoveQueueItemRequest
+	(*SaveQueueRequest)(nil),     // 16: bluesoundplayer.v1.SaveQueueRequest
+	(*ScenePlayer)(nil),          // 17: bluesoundplayer.v1.ScenePlayer
+	(*Scene)(nil),                // 18: bluesoundplayer.v1.Scene
+	(*SceneList)(nil),            // 19: bluesoundplayer.v1.SceneList
+	(*ApplySceneRequest)(nil),    // 20: bluesoundplayer.v1.ApplySceneRequest
+	(*WatchRequest)(nil),         // 21: bluesoundplayer.v1.WatchRequest
+	(*PlayerEvent)(nil),          // 22: bluesoundplayer.v1.PlayerEvent
+}
+var file_bluesoundplayer_v1_player_proto_depIdxs = []int32{
+	1,  // 0: bluesoundplayer.v1.PlayerList.players:type_name -> bluesoundplayer.v1.PlayerInfo
+	5,  // 1: bluesoundplayer.v1.PresetList.presets:type_name -> bluesoundplayer.v1.Preset
+	11, // 2: bluesoundplayer.v1.Queue.items:type_name -> bluesoundplayer.v1.QueueItem
+	17, // 3: bluesoundplayer.v1.Scene.players:type_name -> bluesoundplayer.v1.ScenePlayer
+	18, // 4: bluesoundplayer.v1.SceneList.scenes:type_name -> bluesoundplayer.v1.Scene
+	4,  // 5: bluesoundplayer.v1.PlayerEvent.status:type_name -> bluesoundplayer.v1.Status
+	0,  // 6: bluesoundplayer.v1.Player.ListPlayers:input_type -> bluesoundplayer.v1.Empty
+	0,  // 7: bluesoundplayer.v1.Player.Rescan:input_type -> bluesoundplayer.v1.Empty
+	3,  // 8: bluesoundplayer.v1.Player.GetStatus:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 9: bluesoundplayer.v1.Player.GetPresets:input_type -> bluesoundplayer.v1.PlayerRequest
+	7,  // 10: bluesoundplayer.v1.Player.PlayPreset:input_type -> bluesoundplayer.v1.PlayPresetRequest
+	8,  // 11: bluesoundplayer.v1.Player.PlayURL:input_type -> bluesoundplayer.v1.PlayURLRequest
+	3,  // 12: bluesoundplayer.v1.Player.Play:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 13: bluesoundplayer.v1.Player.Pause:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 14: bluesoundplayer.v1.Player.Stop:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 15: bluesoundplayer.v1.Player.Next:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 16: bluesoundplayer.v1.Player.Previous:input_type -> bluesoundplayer.v1.PlayerRequest
+	9,  // 17: bluesoundplayer.v1.Player.SetVolume:input_type -> bluesoundplayer.v1.SetVolumeRequest
+	10, // 18: bluesoundplayer.v1.Player.AddSlaves:input_type -> bluesoundplayer.v1.GroupRequest
+	10, // 19: bluesoundplayer.v1.Player.RemoveSlaves:input_type -> bluesoundplayer.v1.GroupRequest
+	3,  // 20: bluesoundplayer.v1.Player.Ungroup:input_type -> bluesoundplayer.v1.PlayerRequest
+	3,  // 21: bluesoundplayer.v1.Player.GetQueue:input_type -> bluesoundplayer.v1.PlayerRequest
+	13, // 22: bluesoundplayer.v1.Player.AddToQueue:input_type -> bluesoundplayer.v1.AddToQueueRequest
+	14, // 23: bluesoundplayer.v1.Player.RemoveFromQueue:input_type -> bluesoundplayer.v1.QueueIndexRequest
+	15, // 24: bluesoundplayer.v1.Player.MoveQueueItem:input_type -> bluesoundplayer.v1.MoveQueueItemRequest
+	3,  // 25: bluesoundplayer.v1.Player.ClearQueue:input_type -> bluesoundplayer.v1.PlayerRequest
+	14, // 26: bluesoundplayer.v1.Player.PlayQueueItem:input_type -> bluesoundplayer.v1.QueueIndexRequest
+	16, // 27: bluesoundplayer.v1.Player.SaveQueue:input_type -> bluesoundplayer.v1.SaveQueueRequest
+	0,  // 28: bluesoundplayer.v1.Player.ListScenes:input_type -> bluesoundplayer.v1.Empty
+	20, // 29: bluesoundplayer.v1.Player.ApplyScene:input_type -> bluesoundplayer.v1.ApplySceneRequest
+	21, // 30: bluesoundplayer.v1.Player.Watch:input_type -> bluesoundplayer.v1.WatchRequest
+	2,  // 31: bluesoundplayer.v1.Player.ListPlayers:output_type -> bluesoundplayer.v1.PlayerList
+	2,  // 32: bluesoundplayer.v1.Player.Rescan:output_type -> bluesoundplayer.v1.PlayerList
+	4,  // 33: bluesoundplayer.v1.Player.GetStatus:output_type -> bluesoundplayer.v1.Status
+	6,  // 34: bluesoundplayer.v1.Player.GetPresets:output_type -> bluesoundplayer.v1.PresetList
+	4,  // 35: bluesoundplayer.v1.Player.PlayPreset:output_type -> bluesoundplayer.v1.Status
+	4,  // 36: bluesoundplayer.v1.Player.PlayURL:output_type -> bluesoundplayer.v1.Status
+	4,  // 37: bluesoundplayer.v1.Player.Play:output_type -> bluesoundplayer.v1.Status
+	4,  // 38: bluesoundplayer.v1.Player.Pause:output_type -> bluesoundplayer.v1.Status
+	4,  // 39: bluesoundplayer.v1.Player.Stop:output_type -> bluesoundplayer.v1.Status
+	4,  // 40: bluesoundplayer.v1.Player.Next:output_type -> bluesoundplayer.v1.Status
+	4,  // 41: bluesoundplayer.v1.Player.Previous:output_type -> bluesoundplayer.v1.Status
+	4,  // 42: bluesoundplayer.v1.Player.SetVolume:output_type -> bluesoundplayer.v1.Status
+	4,  // 43: bluesoundplayer.v1.Player.AddSlaves:output_type -> bluesoundplayer.v1.Status
+	4,  // 44: bluesoundplayer.v1.Player.RemoveSlaves:output_type -> bluesoundplayer.v1.Status
+	4,  // 45: bluesoundplayer.v1.Player.Ungroup:output_type -> bluesoundplayer.v1.Status
+	12, // 46: bluesoundplayer.v1.Player.GetQueue:output_type -> bluesoundplayer.v1.Queue
+	12, // 47: bluesoundplayer.v1.Player.AddToQueue:output_type -> bluesoundplayer.v1.Queue
+	12, // 48: bluesoundplayer.v1.Player.RemoveFromQueue:output_type -> bluesoundplayer.v1.Queue
+	12, // 49: bluesoundplayer.v1.Player.MoveQueueItem:output_type -> bluesoundplayer.v1.Queue
+	12, // 50: bluesoundplayer.v1.Player.ClearQueue:output_type -> bluesoundplayer.v1.Queue
+	4,  // 51: bluesoundplayer.v1.Player.PlayQueueItem:output_type -> bluesoundplayer.v1.Status
+	0,  // 52: bluesoundplayer.v1.Player.SaveQueue:output_type -> bluesoundplayer.v1.Empty
+	19, // 53: bluesoundplayer.v1.Player.ListScenes:output_type -> bluesoundplayer.v1.SceneList
+	0,  // 54: bluesoundplayer.v1.Player.ApplyScene:output_type -> bluesoundplayer.v1.Empty
+	22, // 55: bluesoundplayer.v1.Player.Watch:output_type -> bluesoundplayer.v1.PlayerEvent
+	31, // [31:56] is the sub-list for method output_type
+	6,  // [6:31] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_bluesoundplayer_v1_player_proto_init() }
+func file_bluesoundplayer_v1_player_proto_init() {
+	if File_bluesoundplayer_v1_player_proto != nil {
+		return
+	}
+	file_bluesoundplayer_v1_player_proto_msgTypes[17].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bluesoundplayer_v1_player_proto_rawDesc), len(file_bluesoundplayer_v1_player_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bluesoundplayer_v1_player_proto_goTypes,
+		DependencyIndexes: file_bluesoundplayer_v1_player_proto_depIdxs,
+		MessageInfos:      file_bluesoundplayer_v1_player_proto_msgTypes,
+	}.Build()
+	File_bluesoundplayer_v1_player_proto = out.File
+	file_bluesoundplayer_v1_player_proto_goTypes = nil
+	file_bluesoundplayer_v1_player_proto_depIdxs = nil
+}
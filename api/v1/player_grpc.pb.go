@@ -0,0 +1,1061 @@
+// gRPC control API of `bluesoundplayer daemon --grpc 127.0.0.1:50051`.
+//
+// The generated Go client lives in the bluesoundplayer/api module (api/v1).
+// Regenerate it from the repository root with:
+//
+//   protoc -I proto \
+//     --go_out=api --go_opt=module=bluesoundplayer/api \
+//     --go-grpc_out=api --go-grpc_opt=module=bluesoundplayer/api \
+//     bluesoundplayer/v1/player.proto
+//
+// Players are addressed by name (case-insensitive) or IP address.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bluesoundplayer/v1/player.proto
+
+package bluesoundplayerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Player_ListPlayers_FullMethodName     = "/bluesoundplayer.v1.Player/ListPlayers"
+	Player_Rescan_FullMethodName          = "/bluesoundplayer.v1.Player/Rescan"
+	Player_GetStatus_FullMethodName       = "/bluesoundplayer.v1.Player/GetStatus"
+	Player_GetPresets_FullMethodName      = "/bluesoundplayer.v1.Player/GetPresets"
+	Player_PlayPreset_FullMethodName      = "/bluesoundplayer.v1.Player/PlayPreset"
+	Player_PlayURL_FullMethodName         = "/bluesoundplayer.v1.Player/PlayURL"
+	Player_Play_FullMethodName            = "/bluesoundplayer.v1.Player/Play"
+	Player_Pause_FullMethodName           = "/bluesoundplayer.v1.Player/Pause"
+	Player_Stop_FullMethodName            = "/bluesoundplayer.v1.Player/Stop"
+	Player_Next_FullMethodName            = "/bluesoundplayer.v1.Player/Next"
+	Player_Previous_FullMethodName        = "/bluesoundplayer.v1.Player/Previous"
+	Player_SetVolume_FullMethodName       = "/bluesoundplayer.v1.Player/SetVolume"
+	Player_AddSlaves_FullMethodName       = "/bluesoundplayer.v1.Player/AddSlaves"
+	Player_RemoveSlaves_FullMethodName    = "/bluesoundplayer.v1.Player/RemoveSlaves"
+	Player_Ungroup_FullMethodName         = "/bluesoundplayer.v1.Player/Ungroup"
+	Player_GetQueue_FullMethodName        = "/bluesoundplayer.v1.Player/GetQueue"
+	Player_AddToQueue_FullMethodName      = "/bluesoundplayer.v1.Player/AddToQueue"
+	Player_RemoveFromQueue_FullMethodName = "/bluesoundplayer.v1.Player/RemoveFromQueue"
+	Player_MoveQueueItem_FullMethodName   = "/bluesoundplayer.v1.Player/MoveQueueItem"
+	Player_ClearQueue_FullMethodName      = "/bluesoundplayer.v1.Player/ClearQueue"
+	Player_PlayQueueItem_FullMethodName   = "/bluesoundplayer.v1.Player/PlayQueueItem"
+	Player_SaveQueue_FullMethodName       = "/bluesoundplayer.v1.Player/SaveQueue"
+	Player_ListScenes_FullMethodName      = "/bluesoundplayer.v1.Player/ListScenes"
+	Player_ApplyScene_FullMethodName      = "/bluesoundplayer.v1.Player/ApplyScene"
+	Player_Watch_FullMethodName           = "/bluesoundplayer.v1.Player/Watch"
+)
+
+// PlayerClient is the client API for Player service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlayerClient interface {
+	// Discovery
+	ListPlayers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlayerList, error)
+	Rescan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlayerList, error)
+	// Playback
+	GetStatus(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	GetPresets(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PresetList, error)
+	PlayPreset(ctx context.Context, in *PlayPresetRequest, opts ...grpc.CallOption) (*Status, error)
+	PlayURL(ctx context.Context, in *PlayURLRequest, opts ...grpc.CallOption) (*Status, error)
+	Play(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	Pause(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	Stop(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	Next(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	Previous(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	SetVolume(ctx context.Context, in *SetVolumeRequest, opts ...grpc.CallOption) (*Status, error)
+	// Groups: the request's player is the master
+	AddSlaves(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Status, error)
+	RemoveSlaves(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Status, error)
+	Ungroup(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error)
+	// Queue
+	GetQueue(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Queue, error)
+	AddToQueue(ctx context.Context, in *AddToQueueRequest, opts ...grpc.CallOption) (*Queue, error)
+	RemoveFromQueue(ctx context.Context, in *QueueIndexRequest, opts ...grpc.CallOption) (*Queue, error)
+	MoveQueueItem(ctx context.Context, in *MoveQueueItemRequest, opts ...grpc.CallOption) (*Queue, error)
+	ClearQueue(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Queue, error)
+	PlayQueueItem(ctx context.Context, in *QueueIndexRequest, opts ...grpc.CallOption) (*Status, error)
+	SaveQueue(ctx context.Context, in *SaveQueueRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Scenes from scenes.json
+	ListScenes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SceneList, error)
+	ApplyScene(ctx context.Context, in *ApplySceneRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Current status of every player, then every change as it happens
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerEvent], error)
+}
+
+type playerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlayerClient(cc grpc.ClientConnInterface) PlayerClient {
+	return &playerClient{cc}
+}
+
+func (c *playerClient) ListPlayers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlayerList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerList)
+	err := c.cc.Invoke(ctx, Player_ListPlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Rescan(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlayerList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerList)
+	err := c.cc.Invoke(ctx, Player_Rescan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetStatus(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetPresets(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PresetList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PresetList)
+	err := c.cc.Invoke(ctx, Player_GetPresets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) PlayPreset(ctx context.Context, in *PlayPresetRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_PlayPreset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) PlayURL(ctx context.Context, in *PlayURLRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_PlayURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Play(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Play_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Pause(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Pause_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Stop(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Next(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Next_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Previous(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Previous_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) SetVolume(ctx context.Context, in *SetVolumeRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_SetVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) AddSlaves(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_AddSlaves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) RemoveSlaves(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_RemoveSlaves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Ungroup(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_Ungroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetQueue(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Queue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, Player_GetQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) AddToQueue(ctx context.Context, in *AddToQueueRequest, opts ...grpc.CallOption) (*Queue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, Player_AddToQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) RemoveFromQueue(ctx context.Context, in *QueueIndexRequest, opts ...grpc.CallOption) (*Queue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, Player_RemoveFromQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) MoveQueueItem(ctx context.Context, in *MoveQueueItemRequest, opts ...grpc.CallOption) (*Queue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, Player_MoveQueueItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) ClearQueue(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*Queue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Queue)
+	err := c.cc.Invoke(ctx, Player_ClearQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) PlayQueueItem(ctx context.Context, in *QueueIndexRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Player_PlayQueueItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) SaveQueue(ctx context.Context, in *SaveQueueRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Player_SaveQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) ListScenes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SceneList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SceneList)
+	err := c.cc.Invoke(ctx, Player_ListScenes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) ApplyScene(ctx context.Context, in *ApplySceneRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Player_ApplyScene_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Player_ServiceDesc.Streams[0], Player_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, PlayerEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Player_WatchClient = grpc.ServerStreamingClient[PlayerEvent]
+
+// PlayerServer is the server API for Player service.
+// All implementations must embed UnimplementedPlayerServer
+// for forward compatibility.
+type PlayerServer interface {
+	// Discovery
+	ListPlayers(context.Context, *Empty) (*PlayerList, error)
+	Rescan(context.Context, *Empty) (*PlayerList, error)
+	// Playback
+	GetStatus(context.Context, *PlayerRequest) (*Status, error)
+	GetPresets(context.Context, *PlayerRequest) (*PresetList, error)
+	PlayPreset(context.Context, *PlayPresetRequest) (*Status, error)
+	PlayURL(context.Context, *PlayURLRequest) (*Status, error)
+	Play(context.Context, *PlayerRequest) (*Status, error)
+	Pause(context.Context, *PlayerRequest) (*Status, error)
+	Stop(context.Context, *PlayerRequest) (*Status, error)
+	Next(context.Context, *PlayerRequest) (*Status, error)
+	Previous(context.Context, *PlayerRequest) (*Status, error)
+	SetVolume(context.Context, *SetVolumeRequest) (*Status, error)
+	// Groups: the request's player is the master
+	AddSlaves(context.Context, *GroupRequest) (*Status, error)
+	RemoveSlaves(context.Context, *GroupRequest) (*Status, error)
+	Ungroup(context.Context, *PlayerRequest) (*Status, error)
+	// Queue
+	GetQueue(context.Context, *PlayerRequest) (*Queue, error)
+	AddToQueue(context.Context, *AddToQueueRequest) (*Queue, error)
+	RemoveFromQueue(context.Context, *QueueIndexRequest) (*Queue, error)
+	MoveQueueItem(context.Context, *MoveQueueItemRequest) (*Queue, error)
+	ClearQueue(context.Context, *PlayerRequest) (*Queue, error)
+	PlayQueueItem(context.Context, *QueueIndexRequest) (*Status, error)
+	SaveQueue(context.Context, *SaveQueueRequest) (*Empty, error)
+	// Scenes from scenes.json
+	ListScenes(context.Context, *Empty) (*SceneList, error)
+	ApplyScene(context.Context, *ApplySceneRequest) (*Empty, error)
+	// Current status of every player, then every change as it happens
+	Watch(*WatchRequest, grpc.ServerStreamingServer[PlayerEvent]) error
+	mustEmbedUnimplementedPlayerServer()
+}
+
+// UnimplementedPlayerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPlayerServer struct{}
+
+func (UnimplementedPlayerServer) ListPlayers(context.Context, *Empty) (*PlayerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlayers not implemented")
+}
+func (UnimplementedPlayerServer) Rescan(context.Context, *Empty) (*PlayerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rescan not implemented")
+}
+func (UnimplementedPlayerServer) GetStatus(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedPlayerServer) GetPresets(context.Context, *PlayerRequest) (*PresetList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPresets not implemented")
+}
+func (UnimplementedPlayerServer) PlayPreset(context.Context, *PlayPresetRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayPreset not implemented")
+}
+func (UnimplementedPlayerServer) PlayURL(context.Context, *PlayURLRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayURL not implemented")
+}
+func (UnimplementedPlayerServer) Play(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Play not implemented")
+}
+func (UnimplementedPlayerServer) Pause(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedPlayerServer) Stop(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedPlayerServer) Next(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Next not implemented")
+}
+func (UnimplementedPlayerServer) Previous(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Previous not implemented")
+}
+func (UnimplementedPlayerServer) SetVolume(context.Context, *SetVolumeRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVolume not implemented")
+}
+func (UnimplementedPlayerServer) AddSlaves(context.Context, *GroupRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSlaves not implemented")
+}
+func (UnimplementedPlayerServer) RemoveSlaves(context.Context, *GroupRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSlaves not implemented")
+}
+func (UnimplementedPlayerServer) Ungroup(context.Context, *PlayerRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ungroup not implemented")
+}
+func (UnimplementedPlayerServer) GetQueue(context.Context, *PlayerRequest) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueue not implemented")
+}
+func (UnimplementedPlayerServer) AddToQueue(context.Context, *AddToQueueRequest) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToQueue not implemented")
+}
+func (UnimplementedPlayerServer) RemoveFromQueue(context.Context, *QueueIndexRequest) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromQueue not implemented")
+}
+func (UnimplementedPlayerServer) MoveQueueItem(context.Context, *MoveQueueItemRequest) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveQueueItem not implemented")
+}
+func (UnimplementedPlayerServer) ClearQueue(context.Context, *PlayerRequest) (*Queue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearQueue not implemented")
+}
+func (UnimplementedPlayerServer) PlayQueueItem(context.Context, *QueueIndexRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayQueueItem not implemented")
+}
+func (UnimplementedPlayerServer) SaveQueue(context.Context, *SaveQueueRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveQueue not implemented")
+}
+func (UnimplementedPlayerServer) ListScenes(context.Context, *Empty) (*SceneList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScenes not implemented")
+}
+func (UnimplementedPlayerServer) ApplyScene(context.Context, *ApplySceneRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyScene not implemented")
+}
+func (UnimplementedPlayerServer) Watch(*WatchRequest, grpc.ServerStreamingServer[PlayerEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedPlayerServer) mustEmbedUnimplementedPlayerServer() {}
+func (UnimplementedPlayerServer) testEmbeddedByValue()                {}
+
+// UnsafePlayerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlayerServer will
+// result in compilation errors.
+type UnsafePlayerServer interface {
+	mustEmbedUnimplementedPlayerServer()
+}
+
+func RegisterPlayerServer(s grpc.ServiceRegistrar, srv PlayerServer) {
+	// If the following call pancis, it indicates UnimplementedPlayerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Player_ServiceDesc, srv)
+}
+
+func _Player_ListPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ListPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_ListPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ListPlayers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Rescan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Rescan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Rescan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Rescan(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetStatus(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetPresets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetPresets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_GetPresets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetPresets(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_PlayPreset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayPresetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).PlayPreset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_PlayPreset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).PlayPreset(ctx, req.(*PlayPresetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_PlayURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).PlayURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_PlayURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).PlayURL(ctx, req.(*PlayURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Play_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Play(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Play_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Play(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Pause(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Stop(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Next_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Next(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Next_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Next(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Previous_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Previous(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Previous_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Previous(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_SetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).SetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_SetVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).SetVolume(ctx, req.(*SetVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_AddSlaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).AddSlaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_AddSlaves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).AddSlaves(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_RemoveSlaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).RemoveSlaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_RemoveSlaves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).RemoveSlaves(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Ungroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Ungroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_Ungroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Ungroup(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_GetQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetQueue(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_AddToQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).AddToQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_AddToQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).AddToQueue(ctx, req.(*AddToQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_RemoveFromQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).RemoveFromQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_RemoveFromQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).RemoveFromQueue(ctx, req.(*QueueIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_MoveQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).MoveQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_MoveQueueItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).MoveQueueItem(ctx, req.(*MoveQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_ClearQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ClearQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_ClearQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ClearQueue(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_PlayQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).PlayQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_PlayQueueItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).PlayQueueItem(ctx, req.(*QueueIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_SaveQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).SaveQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_SaveQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).SaveQueue(ctx, req.(*SaveQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_ListScenes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ListScenes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_ListScenes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ListScenes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_ApplyScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplySceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ApplyScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Player_ApplyScene_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ApplyScene(ctx, req.(*ApplySceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerServer).Watch(m, &grpc.GenericServerStream[WatchRequest, PlayerEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Player_WatchServer = grpc.ServerStreamingServer[PlayerEvent]
+
+// Player_ServiceDesc is the grpc.ServiceDesc for Player service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Player_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bluesoundplayer.v1.Player",
+	HandlerType: (*PlayerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPlayers",
+			Handler:    _Player_ListPlayers_Handler,
+		},
+		{
+			MethodName: "Rescan",
+			Handler:    _Player_Rescan_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Player_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetPresets",
+			Handler:    _Player_GetPresets_Handler,
+		},
+		{
+			MethodName: "PlayPreset",
+			Handler:    _Player_PlayPreset_Handler,
+		},
+		{
+			MethodName: "PlayURL",
+			Handler:    _Player_PlayURL_Handler,
+		},
+		{
+			MethodName: "Play",
+			Handler:    _Player_Play_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Player_Pause_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _Player_Stop_Handler,
+		},
+		{
+			MethodName: "Next",
+			Handler:    _Player_Next_Handler,
+		},
+		{
+			MethodName: "Previous",
+			Handler:    _Player_Previous_Handler,
+		},
+		{
+			MethodName: "SetVolume",
+			Handler:    _Player_SetVolume_Handler,
+		},
+		{
+			MethodName: "AddSlaves",
+			Handler:    _Player_AddSlaves_Handler,
+		},
+		{
+			MethodName: "RemoveSlaves",
+			Handler:    _Player_RemoveSlaves_Handler,
+		},
+		{
+			MethodName: "Ungroup",
+			Handler:    _Player_Ungroup_Handler,
+		},
+		{
+			MethodName: "GetQueue",
+			Handler:    _Player_GetQueue_Handler,
+		},
+		{
+			MethodName: "AddToQueue",
+			Handler:    _Player_AddToQueue_Handler,
+		},
+		{
+			MethodName: "RemoveFromQueue",
+			Handler:    _Player_RemoveFromQueue_Handler,
+		},
+		{
+			MethodName: "MoveQueueItem",
+			Handler:    _Player_MoveQueueItem_Handler,
+		},
+		{
+			MethodName: "ClearQueue",
+			Handler:    _Player_ClearQueue_Handler,
+		},
+		{
+			MethodName: "PlayQueueItem",
+			Handler:    _Player_PlayQueueItem_Handler,
+		},
+		{
+			MethodName: "SaveQueue",
+			Handler:    _Player_SaveQueue_Handler,
+		},
+		{
+			MethodName: "ListScenes",
+			Handler:    _Player_ListScenes_Handler,
+		},
+		{
+			MethodName: "ApplyScene",
+			Handler:    _Player_ApplyScene_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Player_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bluesoundplayer/v1/player.proto",
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Weekday bit mask, bit 0 = Sunday ... bit 6 = Saturday
+type WeekdayMask uint8
+
+const (
+	everyDay    WeekdayMask = 0x7f
+	workingDays WeekdayMask = 0x3e
+	weekendDays WeekdayMask = 0x41
+)
+
+const (
+	alarmsFile         = "alarms.json"
+	defaultAlarmVolume = 20
+)
+
+// How long a locally scheduled alarm fades in
+const alarmFadeDuration = 60 * time.Second
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func (m WeekdayMask) Has(day time.Weekday) bool {
+	return m&(1<<uint(day)) != 0
+}
+
+func (m WeekdayMask) String() string {
+	switch m {
+	case 0:
+		return "once"
+	case everyDay:
+		return "daily"
+	case workingDays:
+		return "weekdays"
+	case weekendDays:
+		return "weekends"
+	}
+
+	var days []string
+	for day, name := range weekdayNames {
+		if m.Has(time.Weekday(day)) {
+			days = append(days, name)
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+// Parse "once", "daily", "weekdays", "weekends" or lists like "mon,wed,fri"
+// and "mon-fri"
+func parseWeekdays(spec string) (WeekdayMask, error) {
+	switch strings.ToLower(spec) {
+	case "once":
+		return 0, nil
+	case "daily", "everyday":
+		return everyDay, nil
+	case "weekdays":
+		return workingDays, nil
+	case "weekends":
+		return weekendDays, nil
+	}
+
+	var mask WeekdayMask
+	for _, part := range strings.Split(strings.ToLower(spec), ",") {
+		from, to := part, part
+		if idx := strings.Index(part, "-"); idx != -1 {
+			from, to = part[:idx], part[idx+1:]
+		}
+		start, ok := weekdayIndex(from)
+		if !ok {
+			return 0, fmt.Errorf("unknown weekday %q", from)
+		}
+		end, ok := weekdayIndex(to)
+		if !ok {
+			return 0, fmt.Errorf("unknown weekday %q", to)
+		}
+		for day := start; ; day = (day + 1) % 7 {
+			mask |= 1 << uint(day)
+			if day == end {
+				break
+			}
+		}
+	}
+	return mask, nil
+}
+
+func weekdayIndex(name string) (int, bool) {
+	for i, day := range weekdayNames {
+		if strings.HasPrefix(name, day) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Parse "7:30" or "07:30" into a normalized "07:30"
+func parseAlarmTime(value string) (string, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", false
+	}
+	return t.Format("15:04"), true
+}
+
+// Next time the alarm rings after now
+func nextAlarmTime(alarm Alarm, now time.Time) (time.Time, bool) {
+	t, err := time.Parse("15:04", alarm.Time)
+	if err != nil || !alarm.Enabled {
+		return time.Time{}, false
+	}
+
+	for d := 0; d <= 7; d++ {
+		day := now.AddDate(0, 0, d)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !candidate.After(now) {
+			continue
+		}
+		if alarm.Days == 0 || alarm.Days.Has(candidate.Weekday()) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// The enabled alarm that rings next
+func nextAlarm(alarms []Alarm, now time.Time) (Alarm, time.Time, bool) {
+	var next Alarm
+	var nextTime time.Time
+	found := false
+	for _, alarm := range alarms {
+		if t, ok := nextAlarmTime(alarm, now); ok && (!found || t.Before(nextTime)) {
+			next, nextTime, found = alarm, t, true
+		}
+	}
+	return next, nextTime, found
+}
+
+// Alarms for players without an alarm clock of their own (BluOS) are kept
+// by the app in alarms.json and rung by its scheduler
+type LocalAlarm struct {
+	Alarm
+	Player string `json:"player"`
+}
+
+type alarmStore struct {
+	Alarms []LocalAlarm `json:"alarms"`
+
+	path string
+}
+
+// Guards alarms.json between the TUI and the scheduler
+var alarmStoreMu sync.Mutex
+
+func loadAlarmStore() (*alarmStore, error) {
+	path, err := configPath(alarmsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &alarmStore{path: path}
+	if err := loadJSONFile(path, store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *alarmStore) Save() error {
+	return saveJSONFile(s.path, s)
+}
+
+// Run fn on the loaded store and save it afterwards when fn succeeds
+func updateAlarmStore(fn func(store *alarmStore) error) error {
+	alarmStoreMu.Lock()
+	defer alarmStoreMu.Unlock()
+
+	store, err := loadAlarmStore()
+	if err != nil {
+		return err
+	}
+	if err := fn(store); err != nil {
+		return err
+	}
+	return store.Save()
+}
+
+func localAlarms(player string) ([]Alarm, error) {
+	alarmStoreMu.Lock()
+	defer alarmStoreMu.Unlock()
+
+	store, err := loadAlarmStore()
+	if err != nil {
+		return nil, err
+	}
+
+	var alarms []Alarm
+	for _, alarm := range store.Alarms {
+		if alarm.Player == player {
+			alarms = append(alarms, alarm.Alarm)
+		}
+	}
+	return alarms, nil
+}
+
+func addLocalAlarm(player string, alarm Alarm) error {
+	return updateAlarmStore(func(store *alarmStore) error {
+		alarm.ID = 1
+		for _, existing := range store.Alarms {
+			if existing.ID >= alarm.ID {
+				alarm.ID = existing.ID + 1
+			}
+		}
+		store.Alarms = append(store.Alarms, LocalAlarm{Alarm: alarm, Player: player})
+		return nil
+	})
+}
+
+func setLocalAlarmEnabled(player string, id int, enabled bool) error {
+	return updateAlarmStore(func(store *alarmStore) error {
+		for i := range store.Alarms {
+			if store.Alarms[i].Player == player && store.Alarms[i].ID == id {
+				store.Alarms[i].Enabled = enabled
+				return nil
+			}
+		}
+		return fmt.Errorf("alarm %d not found", id)
+	})
+}
+
+func removeLocalAlarm(player string, id int) error {
+	return updateAlarmStore(func(store *alarmStore) error {
+		for i, alarm := range store.Alarms {
+			if alarm.Player == player && alarm.ID == id {
+				store.Alarms = append(store.Alarms[:i], store.Alarms[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("alarm %d not found", id)
+	})
+}
+
+// Check the local alarms once a minute while the app is running
+func startAlarmScheduler() {
+	go func() {
+		var lastMinute time.Time
+		ticker := time.NewTicker(15 * time.Second)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			minute := now.Truncate(time.Minute)
+			if minute.Equal(lastMinute) {
+				continue
+			}
+			lastMinute = minute
+			ringDueAlarms(now)
+		}
+	}()
+}
+
+func ringDueAlarms(now time.Time) {
+	updateAlarmStore(func(store *alarmStore) error {
+		for i, alarm := range store.Alarms {
+			if !alarm.Enabled || alarm.Time != now.Format("15:04") {
+				continue
+			}
+			if alarm.Days != 0 && !alarm.Days.Has(now.Weekday()) {
+				continue
+			}
+
+			go func() {
+				if err := ringLocalAlarm(alarm); err != nil {
+					log.Printf("alarm %d on %s failed: %v", alarm.ID, alarm.Player, err)
+				}
+			}()
+
+			// One-off alarms switch themselves off
+			if alarm.Days == 0 {
+				store.Alarms[i].Enabled = false
+			}
+		}
+		return nil
+	})
+}
+
+// Start the preset silently and fade in to the alarm volume
+func ringLocalAlarm(alarm LocalAlarm) error {
+	client := NewBluesoundClient(alarm.Player)
+	if err := client.SetVolume(0); err != nil {
+		return err
+	}
+	if err := client.PlayPreset(alarm.PresetID); err != nil {
+		return err
+	}
+	fade := startFade(client, Fade{Target: alarm.Volume, Duration: alarmFadeDuration, Curve: FadeCurved})
+	<-fade.done
+	// A cancelled fade means someone set the volume, which is fine
+	if fade.cancelled {
+		return nil
+	}
+	return fade.err
+}
+
+// TUI
+
+func updateAlarms() {
+	tuiState.alarms = nil
+	if manager, ok := tuiState.client.(AlarmManager); ok {
+		tuiState.alarms, _ = manager.ListAlarms()
+	}
+}
+
+// Header line for the alarm that rings next
+func renderNextAlarm() {
+	alarm, at, ok := nextAlarm(tuiState.alarms, time.Now())
+	if !ok {
+		return
+	}
+	fmt.Printf(getText("next_alarm")+"\n", at.Format("Mon 15:04"), alarm.Title)
+}
+
+func formatAlarms(alarms []Alarm) string {
+	if len(alarms) == 0 {
+		return getText("no_alarms")
+	}
+
+	lines := []string{getText("alarms_title")}
+	for _, alarm := range alarms {
+		state := "✅"
+		if !alarm.Enabled {
+			state = "⏸️"
+		}
+		lines = append(lines, fmt.Sprintf("  [%d] %s %s %s - %s (%d%%)", alarm.ID, state, alarm.Time, alarm.Days, alarm.Title, alarm.Volume))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Handle "alarm [list|add|remove|enable|disable]"
+func handleAlarmCommand(args []string) {
+	manager, ok := tuiState.client.(AlarmManager)
+	if !ok {
+		reportError(getText("alarms_not_supported"))
+		return
+	}
+
+	if len(args) == 0 || strings.ToLower(args[0]) == "list" {
+		alarms, err := manager.ListAlarms()
+		if err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_alarm"), err))
+			return
+		}
+		tuiState.alarms = alarms
+		tuiState.lastAction = formatAlarms(alarms)
+		return
+	}
+
+	var err error
+	switch strings.ToLower(args[0]) {
+	case "add":
+		// alarm add <HH:MM> <days> <preset> [volume]
+		if len(args) < 4 {
+			reportUsage(getText("alarm_usage"))
+			return
+		}
+		alarm := Alarm{Volume: defaultAlarmVolume, Enabled: true}
+		var valid bool
+		if alarm.Time, valid = parseAlarmTime(args[1]); !valid {
+			reportUsage(getText("alarm_usage"))
+			return
+		}
+		if alarm.Days, err = parseWeekdays(args[2]); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_alarm"), err))
+			return
+		}
+		if alarm.PresetID, valid = parsePresetID(args[3]); !valid {
+			return
+		}
+		if len(args) > 4 {
+			if alarm.Volume, err = strconv.Atoi(args[4]); err != nil || alarm.Volume < 0 || alarm.Volume > 100 {
+				reportUsage(getText("invalid_volume"))
+				return
+			}
+		}
+		err = manager.AddAlarm(alarm)
+		if err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("alarm_added"), alarm.Time, alarm.Days)
+		}
+
+	case "remove", "rm":
+		id, valid := alarmID(args)
+		if !valid {
+			return
+		}
+		err = manager.RemoveAlarm(id)
+		if err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("alarm_removed"), id)
+		}
+
+	case "enable", "disable":
+		id, valid := alarmID(args)
+		if !valid {
+			return
+		}
+		enabled := strings.ToLower(args[0]) == "enable"
+		err = manager.SetAlarmEnabled(id, enabled)
+		if err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("alarm_toggled"), id, onOff(enabled))
+		}
+
+	default:
+		reportUsage(getText("alarm_usage"))
+		return
+	}
+
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_alarm"), err))
+	}
+	updateAlarms()
+}
+
+func alarmID(args []string) (int, bool) {
+	if len(args) < 2 {
+		reportUsage(getText("alarm_usage"))
+		return 0, false
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil || id < 1 {
+		reportUsage(getText("alarm_usage"))
+		return 0, false
+	}
+	return id, true
+}
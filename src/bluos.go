@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,36 +20,93 @@ type SyncStatus struct {
 	Model   string   `xml:"model,attr"`
 }
 
+type BluOSPlaylist struct {
+	XMLName xml.Name    `xml:"playlist"`
+	Name    string      `xml:"name,attr"`
+	Length  int         `xml:"length,attr"`
+	Songs   []BluOSSong `xml:"song"`
+}
+
+type BluOSSong struct {
+	ID     int    `xml:"id,attr"`
+	Title  string `xml:"title"`
+	Artist string `xml:"art"`
+	Album  string `xml:"alb"`
+	File   string `xml:"fn"`
+}
+
+type BluOSBrowse struct {
+	XMLName     xml.Name              `xml:"browse"`
+	ServiceName string                `xml:"serviceName,attr"`
+	NextKey     string                `xml:"nextKey,attr"`
+	Items       []BluOSBrowseItem     `xml:"item"`
+	Categories  []BluOSBrowseCategory `xml:"category"`
+}
+
+type BluOSBrowseCategory struct {
+	Text  string            `xml:"text,attr"`
+	Items []BluOSBrowseItem `xml:"item"`
+}
+
+type BluOSBrowseItem struct {
+	Text        string `xml:"text,attr"`
+	Text2       string `xml:"text2,attr"`
+	Image       string `xml:"image,attr"`
+	Type        string `xml:"type,attr"`
+	BrowseKey   string `xml:"browseKey,attr"`
+	PlayURL     string `xml:"playURL,attr"`
+	AutoplayURL string `xml:"autoplayURL,attr"`
+}
+
+// How long browse levels are served from the cache
+const browseCacheTTL = 5 * time.Minute
+
+type browseCacheEntry struct {
+	result  *BrowseResult
+	fetched time.Time
+}
+
 // BluOS API Client
 type BluesoundClient struct {
-	baseURL string
-	client  *http.Client
+	ip          string
+	baseURL     string
+	client      *http.Client
+	browseMu    sync.Mutex
+	browseCache map[string]browseCacheEntry
 }
 
 func NewBluesoundClient(ip string) *BluesoundClient {
 	return &BluesoundClient{
+		ip:      ip,
 		baseURL: fmt.Sprintf("http://%s:%s", ip, BluesoundPort),
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		browseCache: make(map[string]browseCacheEntry),
 	}
 }
 
 // BluOS API methods
 func (bc *BluesoundClient) makeRequest(endpoint string) ([]byte, error) {
+	start, errorKind := time.Now(), ""
+	defer func() { observeRequest("bluos", endpoint, start, errorKind) }()
+
 	url := bc.baseURL + endpoint
 	resp, err := bc.client.Get(url)
 	if err != nil {
+		errorKind = requestErrorKind(err)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		errorKind = "http_status"
 		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		errorKind = "read"
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
@@ -87,6 +147,120 @@ func (bc *BluesoundClient) PlayPreset(id int) error {
 	return err
 }
 
+func (bc *BluesoundClient) PlayURL(streamURL, title string) error {
+	resolved, err := resolveStreamURL(streamURL)
+	if err != nil {
+		return err
+	}
+	endpoint := "/Play?url=" + url.QueryEscape(resolved)
+	_, err = bc.makeRequest(endpoint)
+	return err
+}
+
+// Preset management
+func (bc *BluesoundClient) CurrentStream() (*Preset, error) {
+	status, err := bc.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	if status.StreamURL == "" {
+		return nil, fmt.Errorf("no stream playing")
+	}
+
+	name := status.Song
+	if name == "" {
+		name = streamTitle(status.StreamURL)
+	}
+
+	return &Preset{Name: name, URL: status.StreamURL, Image: status.Image}, nil
+}
+
+func (bc *BluesoundClient) findPreset(id int) (*Preset, error) {
+	presets, err := bc.GetPresets()
+	if err != nil {
+		return nil, err
+	}
+	for _, preset := range presets {
+		if preset.ID == id {
+			return &preset, nil
+		}
+	}
+	return nil, fmt.Errorf("preset %d not found", id)
+}
+
+func (bc *BluesoundClient) SavePreset(preset Preset) error {
+	if preset.ID == 0 {
+		presets, err := bc.GetPresets()
+		if err != nil {
+			return err
+		}
+		preset.ID = nextFreePresetSlot(presets)
+	}
+
+	params := url.Values{}
+	params.Set("id", fmt.Sprintf("%d", preset.ID))
+	params.Set("name", preset.Name)
+	params.Set("url", preset.URL)
+	if preset.Image != "" {
+		params.Set("image", preset.Image)
+	}
+
+	_, err := bc.makeRequest("/AddPreset?" + params.Encode())
+	return err
+}
+
+func (bc *BluesoundClient) RenamePreset(id int, name string) error {
+	preset, err := bc.findPreset(id)
+	if err != nil {
+		return err
+	}
+	preset.Name = name
+	return bc.SavePreset(*preset)
+}
+
+func (bc *BluesoundClient) DeletePreset(id int) error {
+	endpoint := fmt.Sprintf("/RemovePreset?id=%d", id)
+	_, err := bc.makeRequest(endpoint)
+	return err
+}
+
+// Move a preset to another slot, swapping with the preset already there
+func (bc *BluesoundClient) MovePreset(from, to int) error {
+	if from == to {
+		return nil
+	}
+
+	preset, err := bc.findPreset(from)
+	if err != nil {
+		return err
+	}
+
+	occupant, _ := bc.findPreset(to)
+
+	preset.ID = to
+	if err := bc.SavePreset(*preset); err != nil {
+		return err
+	}
+
+	if occupant != nil {
+		occupant.ID = from
+		return bc.SavePreset(*occupant)
+	}
+	return bc.DeletePreset(from)
+}
+
+func nextFreePresetSlot(presets []Preset) int {
+	used := make(map[int]bool)
+	for _, preset := range presets {
+		used[preset.ID] = true
+	}
+	slot := 1
+	for used[slot] {
+		slot++
+	}
+	return slot
+}
+
 func (bc *BluesoundClient) Play() error {
 	_, err := bc.makeRequest("/Play")
 	return err
@@ -111,6 +285,92 @@ func (bc *BluesoundClient) SetVolume(level int) error {
 	return err
 }
 
+// BluOS only knows these sleep timer steps; /Sleep advances to the next
+// one and switches the timer off after the last
+var bluosSleepSteps = []int{15, 30, 45, 60, 90}
+
+type bluosSleepStatus struct {
+	XMLName xml.Name `xml:"status"`
+	Sleep   int      `xml:"sleep"`
+}
+
+func (bc *BluesoundClient) sleepMinutes() (int, error) {
+	data, err := bc.makeRequest("/Status")
+	if err != nil {
+		return 0, err
+	}
+
+	var status bluosSleepStatus
+	if err := xml.Unmarshal(data, &status); err != nil {
+		return 0, fmt.Errorf("failed to parse status XML: %w", err)
+	}
+	return status.Sleep, nil
+}
+
+func (bc *BluesoundClient) SetSleepTimer(d time.Duration) error {
+	target := int(d / time.Minute)
+	if d%time.Minute != 0 {
+		return fmt.Errorf("BluOS sleep timer only supports %v minutes", bluosSleepSteps)
+	}
+	if target != 0 {
+		supported := false
+		for _, step := range bluosSleepSteps {
+			if step == target {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("BluOS sleep timer only supports %v minutes", bluosSleepSteps)
+		}
+	}
+
+	// Cycle through the steps until the player reports the requested one
+	for i := 0; i <= len(bluosSleepSteps); i++ {
+		current, err := bc.sleepMinutes()
+		if err != nil {
+			return err
+		}
+		if current == target {
+			return nil
+		}
+		if _, err := bc.makeRequest("/Sleep"); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("player did not accept a %d minute sleep timer", target)
+}
+
+func (bc *BluesoundClient) SleepTimerRemaining() (time.Duration, error) {
+	minutes, err := bc.sleepMinutes()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// BluOS has no alarm API, so alarms are scheduled by the app
+func (bc *BluesoundClient) ListAlarms() ([]Alarm, error) {
+	return localAlarms(bc.ip)
+}
+
+func (bc *BluesoundClient) AddAlarm(alarm Alarm) error {
+	preset, err := bc.findPreset(alarm.PresetID)
+	if err != nil {
+		return err
+	}
+	alarm.Title = preset.Name
+	return addLocalAlarm(bc.ip, alarm)
+}
+
+func (bc *BluesoundClient) SetAlarmEnabled(id int, enabled bool) error {
+	return setLocalAlarmEnabled(bc.ip, id, enabled)
+}
+
+func (bc *BluesoundClient) RemoveAlarm(id int) error {
+	return removeLocalAlarm(bc.ip, id)
+}
+
 func (bc *BluesoundClient) Next() error {
 	_, err := bc.makeRequest("/Skip")
 	return err
@@ -143,6 +403,313 @@ func (bc *BluesoundClient) LeaveGroup() error {
 	return err
 }
 
+// Queue management (BluOS song ids are 0-based positions in the play queue)
+func (bc *BluesoundClient) GetQueue() ([]QueueItem, error) {
+	data, err := bc.makeRequest("/Playlist")
+	if err != nil {
+		return nil, err
+	}
+
+	var playlist BluOSPlaylist
+	if err := xml.Unmarshal(data, &playlist); err != nil {
+		return nil, fmt.Errorf("failed to parse playlist XML: %w", err)
+	}
+
+	var queue []QueueItem
+	for _, song := range playlist.Songs {
+		queue = append(queue, QueueItem{
+			Index:  song.ID + 1,
+			Title:  song.Title,
+			Artist: song.Artist,
+			Album:  song.Album,
+			URI:    song.File,
+		})
+	}
+
+	return queue, nil
+}
+
+func (bc *BluesoundClient) AddToQueue(uri, title string, next bool) error {
+	resolved, err := resolveStreamURL(uri)
+	if err != nil {
+		return err
+	}
+
+	where := "last"
+	if next {
+		where = "nextSong"
+	}
+
+	params := url.Values{}
+	params.Set("playnow", "0")
+	params.Set("where", where)
+	params.Set("url", resolved)
+	if title != "" {
+		params.Set("title", title)
+	}
+
+	_, err = bc.makeRequest("/Add?" + params.Encode())
+	return err
+}
+
+func (bc *BluesoundClient) RemoveFromQueue(index int) error {
+	endpoint := fmt.Sprintf("/Delete?id=%d", index-1)
+	_, err := bc.makeRequest(endpoint)
+	return err
+}
+
+func (bc *BluesoundClient) MoveQueueItem(from, to int) error {
+	endpoint := fmt.Sprintf("/Move?old=%d&new=%d", from-1, to-1)
+	_, err := bc.makeRequest(endpoint)
+	return err
+}
+
+func (bc *BluesoundClient) ClearQueue() error {
+	_, err := bc.makeRequest("/Clear")
+	return err
+}
+
+func (bc *BluesoundClient) PlayQueueItem(index int) error {
+	endpoint := fmt.Sprintf("/Play?id=%d", index-1)
+	_, err := bc.makeRequest(endpoint)
+	return err
+}
+
+func (bc *BluesoundClient) SaveQueue(name string) error {
+	endpoint := "/Save?name=" + url.QueryEscape(name)
+	_, err := bc.makeRequest(endpoint)
+	return err
+}
+
+// Content browsing via /Browse?key=...
+func (bc *BluesoundClient) Browse(key string) (*BrowseResult, error) {
+	bc.browseMu.Lock()
+	entry, ok := bc.browseCache[key]
+	bc.browseMu.Unlock()
+	if ok && time.Since(entry.fetched) < browseCacheTTL {
+		return entry.result.copy(), nil
+	}
+
+	endpoint := "/Browse"
+	if key != "" {
+		endpoint += "?key=" + url.QueryEscape(key)
+	}
+
+	data, err := bc.makeRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseBluOSBrowse(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	bc.browseMu.Lock()
+	bc.browseCache[key] = browseCacheEntry{result: result, fetched: time.Now()}
+	bc.browseMu.Unlock()
+	// Callers may change what they get (e.g. set a title), not the cache
+	return result.copy(), nil
+}
+
+func parseBluOSBrowse(key string, data []byte) (*BrowseResult, error) {
+	var browse BluOSBrowse
+	if err := xml.Unmarshal(data, &browse); err != nil {
+		return nil, fmt.Errorf("failed to parse browse XML: %w", err)
+	}
+
+	result := &BrowseResult{
+		Key:     key,
+		Title:   browse.ServiceName,
+		NextKey: browse.NextKey,
+	}
+
+	items := browse.Items
+	for _, category := range browse.Categories {
+		items = append(items, category.Items...)
+	}
+
+	for _, item := range items {
+		playURI := item.PlayURL
+		if playURI == "" {
+			playURI = item.AutoplayURL
+		}
+		result.Items = append(result.Items, BrowseItem{
+			Title:    item.Text,
+			Subtitle: item.Text2,
+			Image:    item.Image,
+			Key:      item.BrowseKey,
+			PlayURI:  playURI,
+			Source:   browse.ServiceName,
+		})
+	}
+
+	return result, nil
+}
+
+// Search LocalMusic and every service listed at the top browse level
+func (bc *BluesoundClient) Search(query string) ([]BrowseItem, error) {
+	services := []string{"LocalMusic"}
+	if root, err := bc.Browse(""); err == nil {
+		for _, item := range root.Items {
+			// Service roots have keys like "TuneIn:"
+			if !strings.HasSuffix(item.Key, ":") || strings.Count(item.Key, ":") != 1 {
+				continue
+			}
+			if service := strings.TrimSuffix(item.Key, ":"); service != "LocalMusic" {
+				services = append(services, service)
+			}
+		}
+	}
+
+	var results []BrowseItem
+	var lastErr error
+	for _, service := range services {
+		params := url.Values{}
+		params.Set("service", service)
+		params.Set("expr", query)
+
+		data, err := bc.makeRequest("/Search?" + params.Encode())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		result, err := parseBluOSBrowse("", data)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		for _, item := range result.Items {
+			if item.Source == "" {
+				item.Source = service
+			}
+			results = append(results, item)
+		}
+	}
+
+	if len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return results, nil
+}
+
+func (bc *BluesoundClient) PlayItem(item BrowseItem) error {
+	if item.PlayURI == "" {
+		return fmt.Errorf("item is not playable")
+	}
+	_, err := bc.makeRequest(item.PlayURI)
+	return err
+}
+
+// Queue an item by turning its /Add?playnow=1 action into an append
+func (bc *BluesoundClient) QueueItem(item BrowseItem) error {
+	parsed, err := url.Parse(item.PlayURI)
+	if err != nil || parsed.Path != "/Add" {
+		return fmt.Errorf("item cannot be added to the queue")
+	}
+
+	params := parsed.Query()
+	params.Set("playnow", "0")
+	params.Set("where", "last")
+
+	_, err = bc.makeRequest("/Add?" + params.Encode())
+	return err
+}
+
+// Seconds BluOS holds a long-poll request while nothing changes
+const bluosLongPollTimeout = 100
+
+type bluosEtag struct {
+	Etag string `xml:"etag,attr"`
+}
+
+// Push status and group changes using BluOS long-polling
+func (bc *BluesoundClient) Watch(stop <-chan struct{}, onStatus func(*Status), onTopology func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		bc.longPoll(ctx, "/Status", func(data []byte) {
+			var status Status
+			if err := xml.Unmarshal(data, &status); err == nil {
+				onStatus(&status)
+			}
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		bc.longPoll(ctx, "/SyncStatus", func([]byte) {
+			onTopology()
+		})
+	}()
+	wg.Wait()
+}
+
+// Repeat a long-poll request, calling onChange whenever the etag changes
+func (bc *BluesoundClient) longPoll(ctx context.Context, endpoint string, onChange func([]byte)) {
+	client := &http.Client{Timeout: (bluosLongPollTimeout + 30) * time.Second}
+	etag := ""
+
+	for ctx.Err() == nil {
+		requestURL := fmt.Sprintf("%s%s?timeout=%d", bc.baseURL, endpoint, bluosLongPollTimeout)
+		if etag != "" {
+			requestURL += "&etag=" + url.QueryEscape(etag)
+		}
+
+		data, err := bc.fetch(ctx, client, requestURL)
+		if err != nil {
+			// Player unreachable: retry a bit later
+			select {
+			case <-time.After(5 * time.Second):
+			case <-ctx.Done():
+			}
+			continue
+		}
+
+		var tag bluosEtag
+		xml.Unmarshal(data, &tag)
+		if tag.Etag == "" || tag.Etag != etag {
+			etag = tag.Etag
+			onChange(data)
+		}
+
+		// Without an etag the player answers at once; don't hammer it
+		if tag.Etag == "" {
+			select {
+			case <-time.After(statusPollInterval):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+func (bc *BluesoundClient) fetch(ctx context.Context, client *http.Client, requestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
 func (bc *BluesoundClient) GetDeviceType() DeviceType {
 	return DeviceTypeBluOS
 }
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TUI content navigator. Each browse level is kept on a stack so "up"
+// can return to the previous listing without another request.
+func currentBrowseLevel() *BrowseResult {
+	if len(tuiState.browseStack) == 0 {
+		return nil
+	}
+	return tuiState.browseStack[len(tuiState.browseStack)-1]
+}
+
+func renderBrowser() {
+	level := currentBrowseLevel()
+	if level == nil {
+		return
+	}
+
+	var path []string
+	for _, l := range tuiState.browseStack {
+		if l.Title != "" {
+			path = append(path, l.Title)
+		}
+	}
+	fmt.Printf("%s %s\n", getText("browse_title"), strings.Join(path, " / "))
+
+	if len(level.Items) == 0 {
+		fmt.Printf("  %s\n", getText("browse_empty"))
+	}
+	for i, item := range level.Items {
+		marker := "  "
+		if item.Key != "" {
+			marker = "📁"
+		} else if item.PlayURI != "" || item.PresetID > 0 {
+			marker = "🎵"
+		}
+		fmt.Printf("  [%d] %s %s", i+1, marker, item.Title)
+		if item.Subtitle != "" {
+			fmt.Printf(" - %s", item.Subtitle)
+		}
+		if item.Source != "" && item.Source != level.Title {
+			fmt.Printf(" [%s]", item.Source)
+		}
+		fmt.Println()
+	}
+	if level.NextKey != "" {
+		fmt.Printf("  %s\n", getText("browse_more_available"))
+	}
+	fmt.Println()
+}
+
+func currentBrowser() (Browser, bool) {
+	browser, ok := tuiState.client.(Browser)
+	if !ok {
+		reportError(getText("browse_not_supported"))
+	}
+	return browser, ok
+}
+
+// Look up an item of the current level by its 1-based number
+func browseItemAt(arg string) (*BrowseItem, bool) {
+	level := currentBrowseLevel()
+	if level == nil {
+		reportError(getText("browse_not_open"))
+		return nil, false
+	}
+
+	index, err := strconv.Atoi(arg)
+	if err != nil || index < 1 || index > len(level.Items) {
+		reportUsage(getText("invalid_browse_item"))
+		return nil, false
+	}
+
+	return &level.Items[index-1], true
+}
+
+func openBrowser(key string) {
+	browser, ok := currentBrowser()
+	if !ok {
+		return
+	}
+
+	result, err := browser.Browse(key)
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_browsing"), err))
+		return
+	}
+
+	if result.Title == "" {
+		result.Title = getText("browse_root")
+	}
+	tuiState.browseStack = []*BrowseResult{result}
+	tuiState.lastAction = getText("browse_opened")
+}
+
+func browseInto(arg string) {
+	browser, ok := currentBrowser()
+	if !ok {
+		return
+	}
+
+	item, ok := browseItemAt(arg)
+	if !ok {
+		return
+	}
+	if item.Key == "" {
+		reportError(getText("browse_not_container"))
+		return
+	}
+
+	result, err := browser.Browse(item.Key)
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_browsing"), err))
+		return
+	}
+
+	if result.Title == "" {
+		result.Title = item.Title
+	}
+	tuiState.browseStack = append(tuiState.browseStack, result)
+	tuiState.lastAction = fmt.Sprintf(getText("browse_entered"), item.Title)
+}
+
+func browseUp() {
+	if len(tuiState.browseStack) <= 1 {
+		tuiState.browseStack = nil
+		tuiState.lastAction = getText("browse_closed")
+		return
+	}
+	tuiState.browseStack = tuiState.browseStack[:len(tuiState.browseStack)-1]
+	tuiState.lastAction = getText("browse_up")
+}
+
+// Replace the current level with its next page
+func browseMore() {
+	browser, ok := currentBrowser()
+	if !ok {
+		return
+	}
+
+	level := currentBrowseLevel()
+	if level == nil {
+		reportError(getText("browse_not_open"))
+		return
+	}
+	if level.NextKey == "" {
+		reportError(getText("browse_no_more"))
+		return
+	}
+
+	result, err := browser.Browse(level.NextKey)
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_browsing"), err))
+		return
+	}
+
+	if result.Title == "" {
+		result.Title = level.Title
+	}
+	tuiState.browseStack[len(tuiState.browseStack)-1] = result
+	tuiState.lastAction = getText("browse_next_page")
+}
+
+func browsePlay(arg string, queue bool) {
+	item, ok := browseItemAt(arg)
+	if !ok {
+		return
+	}
+
+	// Presets listed in search results are played through the preset API
+	if item.PresetID > 0 {
+		playPresetItem(item, queue)
+		return
+	}
+
+	browser, ok := currentBrowser()
+	if !ok {
+		return
+	}
+
+	if queue {
+		if err := browser.QueueItem(*item); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_queue"), err))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("browse_queued"), item.Title)
+		updateQueue()
+		return
+	}
+
+	if err := browser.PlayItem(*item); err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_playing_preset"), err))
+		return
+	}
+	tuiState.lastAction = fmt.Sprintf(getText("browse_playing"), item.Title)
+	updateStatus()
+}
+
+// Handle "browse [close|<key>]"
+func handleBrowseCommand(args []string) {
+	if len(args) > 0 && strings.ToLower(args[0]) == "close" {
+		tuiState.browseStack = nil
+		tuiState.lastAction = getText("browse_closed")
+		return
+	}
+	openBrowser(strings.Join(args, " "))
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Non-interactive subcommands for scripts, e.g.
+//
+//	bluesoundplayer play --player Kitchen 3
+//	bluesoundplayer vol --player 192.168.1.10 +5
+//	bluesoundplayer status --json
+
+// Exit codes
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitUsage          = 2
+	exitPlayerNotFound = 3
+)
+
+// Environment variable with the default player for subcommands
+const playerEnvVar = "BLUESOUNDPLAYER_PLAYER"
+
+var errPlayerNotFound = errors.New("player not found")
+
+type cliOptions struct {
+	player  string
+	json    bool
+	fade    time.Duration
+	addr    string
+	metrics string
+	grpc    string
+}
+
+const cliUsage = `Usage: bluesoundplayer [command] [--player <name|IP>] [--json] [args]
+
+Without a command the interactive TUI is started.
+
+Commands:
+  scan                      List players on the network
+  status                    Show what is playing
+  presets                   List presets/favorites
+  play [<id|name>]          Resume playback or play a preset
+  playurl <url> [title]     Play a stream URL
+  pause | next | prev       Transport controls
+  stop [--fade 10s]         Stop playback, optionally fading out
+  vol <0-100|+n|-n>         Set or change the volume
+  group <master> <slave>... Group BluOS players by name or IP
+  scene <name>              Apply a scene from scenes.json
+  history [n]               Last tracks played (recorded by serve and daemon)
+  stats [days]              Top artists and listening time per room
+  serve [--addr 127.0.0.1:8080]
+                            Run the JSON HTTP API (see README)
+  daemon [--metrics :9100] [--grpc 127.0.0.1:50051]
+                            Run scheduled jobs and alarms (see README)
+  <TUI command> [args]      Any other TUI command, e.g. "sleep 30m"
+
+The player defaults to $BLUESOUNDPLAYER_PLAYER, or the only player found.
+Exit codes: 0 ok, 1 command failed, 2 usage error, 3 player not found.
+`
+
+func runCLI(args []string) int {
+	command := strings.ToLower(args[0])
+	interactive = false
+	scanOutput = io.Discard
+
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, cliUsage) }
+
+	opts := cliOptions{}
+	fs.StringVar(&opts.player, "player", os.Getenv(playerEnvVar), "player name or IP address")
+	fs.StringVar(&opts.player, "p", os.Getenv(playerEnvVar), "player name or IP address (short)")
+	fs.BoolVar(&opts.json, "json", false, "print JSON")
+	fs.DurationVar(&opts.fade, "fade", 0, "fade out before stopping")
+	fs.StringVar(&opts.addr, "addr", "127.0.0.1:8080", "listen address for serve")
+	fs.StringVar(&opts.metrics, "metrics", "", "listen address for daemon metrics")
+	fs.StringVar(&opts.grpc, "grpc", "", "listen address for the daemon's gRPC API")
+
+	positional, err := parseCLIArgs(fs, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	switch command {
+	case "help", "-h", "--help":
+		fmt.Print(cliUsage)
+		return exitOK
+
+	case "daemon":
+		if err := runDaemon(opts.metrics, opts.grpc); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		return exitOK
+
+	case "serve":
+		if err := runServer(opts.addr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		return exitOK
+
+	case "scan":
+		players, err := scanForPlayers()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		if opts.json {
+			return printJSON(players)
+		}
+		for _, player := range players {
+			fmt.Printf("%s\t%s\t%s %s\t%s\n", player.IP, player.Name, player.Brand, player.Model, player.Type)
+		}
+		return exitOK
+
+	case "group":
+		return cliGroup(positional)
+
+	case "history", "stats":
+		return cliHistory(command, positional, opts.json)
+	}
+
+	// Unknown commands are usage errors, no need to look for players first
+	if !slices.Contains(tuiCommands, command) {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, cliUsage)
+		return exitUsage
+	}
+
+	player, players, err := resolveCLIPlayer(opts.player)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errPlayerNotFound) {
+			return exitPlayerNotFound
+		}
+		return exitUsage
+	}
+
+	client, err := newClientForPlayer(player)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	tuiState.client = client
+	tuiState.playerName = player.Name
+	tuiState.availablePlayers = players
+	tuiState.library, _ = loadLibrary()
+
+	switch command {
+	case "status":
+		status, err := client.GetStatus()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		if opts.json {
+			return printJSON(status)
+		}
+		fmt.Printf("%s\t%d%%\t%s", status.State, status.Volume, status.Song)
+		if status.Artist != "" {
+			fmt.Printf(" - %s", status.Artist)
+		}
+		fmt.Println()
+		return exitOK
+
+	case "presets":
+		updatePresets()
+		if tuiState.presetsError != "" {
+			fmt.Fprintln(os.Stderr, tuiState.presetsError)
+			return exitFailure
+		}
+		if opts.json {
+			return printJSON(tuiState.presets)
+		}
+		for _, preset := range tuiState.presets {
+			fmt.Printf("%d\t%s\n", preset.ID, preset.Name)
+		}
+		return exitOK
+
+	case "vol", "volume":
+		if len(positional) != 1 {
+			fmt.Fprint(os.Stderr, cliUsage)
+			return exitUsage
+		}
+		return cliVolume(client, positional[0])
+
+	case "stop":
+		if opts.fade > 0 {
+			// The process has to stay alive until the fade is done
+			if !<-fadeOutAndStop(client, opts.fade) {
+				fmt.Fprintln(os.Stderr, "fade cancelled")
+				return exitFailure
+			}
+			fmt.Println(getText("stopped"))
+			return exitOK
+		}
+	}
+
+	// Everything else runs like the TUI command of the same name
+	return cliRunCommand(append([]string{command}, positional...))
+}
+
+// Parse flags anywhere between the positional arguments. Negative numbers
+// ("vol -5") are arguments, not flags.
+func parseCLIArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		if _, err := strconv.Atoi(args[0]); err == nil {
+			positional = append(positional, args[0])
+			args = args[1:]
+			continue
+		}
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+	return positional, nil
+}
+
+// Find the player given by name or IP; without one, the only player on the
+// network. Also returns all scanned players for grouping commands.
+func resolveCLIPlayer(spec string) (PlayerInfo, []PlayerInfo, error) {
+	if net.ParseIP(spec) != nil {
+		if player, ok := checkForBluOSPlayer(spec); ok {
+			return player, []PlayerInfo{player}, nil
+		}
+		if player, ok := checkForSonosPlayer(spec); ok {
+			return player, []PlayerInfo{player}, nil
+		}
+		return PlayerInfo{}, nil, fmt.Errorf("%w at %s", errPlayerNotFound, spec)
+	}
+
+	players, err := scanForPlayers()
+	if err != nil {
+		return PlayerInfo{}, nil, err
+	}
+
+	if spec == "" {
+		switch len(players) {
+		case 0:
+			return PlayerInfo{}, nil, fmt.Errorf("%w: %s", errPlayerNotFound, getText("no_players"))
+		case 1:
+			return players[0], players, nil
+		}
+		return PlayerInfo{}, nil, fmt.Errorf("%d players found, choose one with --player", len(players))
+	}
+
+	player, ok := findPlayer(players, spec)
+	if !ok {
+		return PlayerInfo{}, nil, fmt.Errorf("%w: %q", errPlayerNotFound, spec)
+	}
+	return player, players, nil
+}
+
+// Absolute ("30") or relative ("+5", "-5") volume change
+func cliVolume(client AudioClient, arg string) int {
+	level, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, getText("invalid_volume"))
+		return exitUsage
+	}
+
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		status, err := client.GetStatus()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		level += status.Volume
+	}
+	level = minInt(100, level)
+	if level < 0 {
+		level = 0
+	}
+
+	if err := client.SetVolume(level); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	fmt.Printf(getText("volume_set")+"\n", level)
+	return exitOK
+}
+
+// group <master> <slave>...
+func cliGroup(names []string) int {
+	if len(names) < 2 {
+		fmt.Fprint(os.Stderr, cliUsage)
+		return exitUsage
+	}
+
+	players, err := scanForPlayers()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+
+	var group []PlayerInfo
+	for _, name := range names {
+		player, ok := findPlayer(players, name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "%v: %q\n", errPlayerNotFound, name)
+			return exitPlayerNotFound
+		}
+		if player.Type != DeviceTypeBluOS {
+			fmt.Fprintln(os.Stderr, "❌ Grouping only supported for BluOS devices")
+			return exitFailure
+		}
+		group = append(group, player)
+	}
+
+	master := NewBluesoundClient(group[0].IP)
+	for _, slave := range group[1:] {
+		if err := master.AddSlave(slave.IP); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", getText("error_grouping"), err)
+			return exitFailure
+		}
+	}
+	fmt.Printf(getText("grouped_players")+"\n", group[0].Name)
+	return exitOK
+}
+
+// history [n] / stats [days]; they don't need a player
+func cliHistory(command string, args []string, asJSON bool) int {
+	if !asJSON {
+		return cliRunCommand(append([]string{command}, args...))
+	}
+
+	fallback := defaultHistoryCount
+	if command == "stats" {
+		fallback = defaultStatsDays
+	}
+	n, ok := parseHistoryCount(args, fallback)
+	if !ok {
+		fmt.Fprint(os.Stderr, cliUsage)
+		return exitUsage
+	}
+	entries, err := loadHistory()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	if command == "stats" {
+		return printJSON(historyStats(entries, statsSince(n)))
+	}
+	return printJSON(recentHistory(entries, n))
+}
+
+// Run a TUI command and report its result
+func cliRunCommand(parts []string) int {
+	tuiState.lastAction = ""
+	executeCommand(strings.Join(parts, " "))
+
+	result := tuiState.lastAction
+	switch tuiState.lastOutcome {
+	case outcomeFailed:
+		fmt.Fprintln(os.Stderr, result)
+		return exitFailure
+	case outcomeUsage:
+		fmt.Fprintln(os.Stderr, result)
+		return exitUsage
+	}
+	if result != "" {
+		fmt.Println(result)
+	}
+	return exitOK
+}
+
+func printJSON(v interface{}) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	fmt.Println(string(data))
+	return exitOK
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/xml"
+	"slices"
+	"time"
 )
 
 // Device type enumeration
@@ -19,28 +21,69 @@ type Presets struct {
 }
 
 type Preset struct {
-	ID    int    `xml:"id,attr"`
-	Name  string `xml:"name,attr"`
-	URL   string `xml:"url,attr"`
-	Image string `xml:"image,attr"`
+	ID    int    `xml:"id,attr" json:"id"`
+	Name  string `xml:"name,attr" json:"name"`
+	URL   string `xml:"url,attr" json:"url"`
+	Image string `xml:"image,attr,omitempty" json:"image,omitempty"`
 }
 
 type Status struct {
-	XMLName xml.Name `xml:"status"`
-	State   string   `xml:"state"`
-	Song    string   `xml:"song"`
-	Artist  string   `xml:"artist"`
-	Album   string   `xml:"album"`
-	Volume  int      `xml:"volume"`
+	XMLName   xml.Name `xml:"status" json:"-"`
+	State     string   `xml:"state" json:"state"`
+	Song      string   `xml:"song" json:"song"`
+	Artist    string   `xml:"artist" json:"artist"`
+	Album     string   `xml:"album" json:"album"`
+	Volume    int      `xml:"volume" json:"volume"`
+	StreamURL string   `xml:"streamUrl" json:"streamUrl,omitempty"`
+	Image     string   `xml:"image" json:"image,omitempty"`
+	Service   string   `xml:"service" json:"service,omitempty"` // BluOS only, e.g. "TuneIn" or "Spotify"
+	Position  int      `xml:"secs" json:"position,omitempty"`   // seconds into the track
+	Duration  int      `xml:"totlen" json:"duration,omitempty"` // track length in seconds, 0 for streams
+}
+
+// Entry of a player's play queue (Index is 1-based)
+type QueueItem struct {
+	Index  int
+	Title  string
+	Artist string
+	Album  string
+	URI    string
+}
+
+// Entry of a content browser listing. Key is set for items that can be
+// opened further, PlayURI/Meta for items that can be played or queued.
+type BrowseItem struct {
+	Title    string
+	Subtitle string
+	Image    string
+	Key      string
+	PlayURI  string
+	Meta     string
+	Source   string
+	PresetID int
+}
+
+// One page of a browse level
+type BrowseResult struct {
+	Key     string
+	Title   string
+	Items   []BrowseItem
+	NextKey string
+}
+
+func (r *BrowseResult) copy() *BrowseResult {
+	c := *r
+	c.Items = slices.Clone(r.Items)
+	return &c
 }
 
 // Player info for scan results
 type PlayerInfo struct {
-	IP    string
-	Name  string
-	Brand string
-	Model string
-	Type  DeviceType
+	IP    string     `json:"ip"`
+	Name  string     `json:"name"`
+	Brand string     `json:"brand"`
+	Model string     `json:"model"`
+	Type  DeviceType `json:"type"`
 }
 
 // Generic client interface
@@ -48,6 +91,7 @@ type AudioClient interface {
 	GetPresets() ([]Preset, error)
 	GetStatus() (*Status, error)
 	PlayPreset(id int) error
+	PlayURL(streamURL, title string) error
 	Play() error
 	Pause() error
 	Stop() error
@@ -58,6 +102,82 @@ type AudioClient interface {
 	RemoveSlave(slaveIP string) error
 	RemoveAllSlaves() error
 	LeaveGroup() error
+	GetQueue() ([]QueueItem, error)
+	AddToQueue(uri, title string, next bool) error
+	RemoveFromQueue(index int) error
+	MoveQueueItem(from, to int) error
+	ClearQueue() error
+	PlayQueueItem(index int) error
+	SaveQueue(name string) error
 	GetDeviceType() DeviceType
 	DebugAPI() string
 }
+
+// Optional interface for clients that expose hierarchical content browsing.
+// An empty key browses the top level.
+type Browser interface {
+	Browse(key string) (*BrowseResult, error)
+	PlayItem(item BrowseItem) error
+	QueueItem(item BrowseItem) error
+}
+
+// Optional interface for clients that can search their music services/library
+type Searcher interface {
+	Search(query string) ([]BrowseItem, error)
+}
+
+// Optional interface for clients whose presets/favorites can be edited.
+// SavePreset stores into preset.ID, or the next free slot when it is 0.
+type PresetManager interface {
+	CurrentStream() (*Preset, error)
+	SavePreset(preset Preset) error
+	RenamePreset(id int, name string) error
+	DeletePreset(id int) error
+	MovePreset(from, to int) error
+}
+
+// Optional interface for clients that cache presets and can be told to reload
+type PresetRefresher interface {
+	RefreshPresets()
+}
+
+// Optional interface for clients with a built-in sleep timer. A duration of
+// 0 cancels the timer; SleepTimerRemaining returns 0 when none is set.
+type SleepTimer interface {
+	SetSleepTimer(d time.Duration) error
+	SleepTimerRemaining() (time.Duration, error)
+}
+
+// Optional interface for clients that can ramp the volume natively.
+// RampToVolume returns how long the player takes to reach the target;
+// RampDuration is what a ramp usually takes, as the player picks the speed.
+type VolumeRamper interface {
+	RampToVolume(target int) (time.Duration, error)
+	RampDuration() time.Duration
+}
+
+// Alarm that starts a preset at a local time. Days is a weekday mask
+// (bit 0 = Sunday ... bit 6 = Saturday); 0 means the alarm rings once.
+type Alarm struct {
+	ID       int         `json:"id"`
+	Time     string      `json:"time"`
+	Days     WeekdayMask `json:"days"`
+	PresetID int         `json:"preset"`
+	Volume   int         `json:"volume"`
+	Enabled  bool        `json:"enabled"`
+	Title    string      `json:"title,omitempty"`
+}
+
+// Optional interface for clients that can manage alarms
+type AlarmManager interface {
+	ListAlarms() ([]Alarm, error)
+	AddAlarm(alarm Alarm) error
+	SetAlarmEnabled(id int, enabled bool) error
+	RemoveAlarm(id int) error
+}
+
+// Optional interface for clients that can report changes as they happen
+// instead of being polled. Watch blocks until stop is closed.
+type EventSource interface {
+	Watch(stop <-chan struct{}, onStatus func(*Status), onTopology func())
+}
@@ -0,0 +1,773 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Minimal D-Bus client for the MPRIS interface: session bus connection with
+// EXTERNAL authentication, method calls, replies and signals. Values are
+// marshalled by signature; a{sv} is a map[string]dbusVariant, as a []string
+// and structs are []interface{}.
+
+type dbusObjectPath string
+
+type dbusSignature string
+
+type dbusVariant struct {
+	Signature string
+	Value     interface{}
+}
+
+// Message types
+const (
+	dbusMethodCall   = 1
+	dbusMethodReturn = 2
+	dbusError        = 3
+	dbusSignal       = 4
+)
+
+const dbusNoReplyExpected = 0x1
+
+// Header field codes
+const (
+	dbusFieldPath        = 1
+	dbusFieldInterface   = 2
+	dbusFieldMember      = 3
+	dbusFieldErrorName   = 4
+	dbusFieldReplySerial = 5
+	dbusFieldDestination = 6
+	dbusFieldSender      = 7
+	dbusFieldSignature   = 8
+)
+
+const dbusCallTimeout = 10 * time.Second
+
+type dbusMessage struct {
+	Type        byte
+	Flags       byte
+	Serial      uint32
+	Path        dbusObjectPath
+	Interface   string
+	Member      string
+	ErrorName   string
+	ReplySerial uint32
+	Destination string
+	Sender      string
+	Signature   string
+	Body        []interface{}
+}
+
+type dbusConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+	serial  uint32
+
+	mu      sync.Mutex
+	pending map[uint32]chan *dbusMessage
+
+	// Incoming method calls; closed when the connection ends
+	calls chan *dbusMessage
+	done  chan struct{}
+}
+
+// Connect to the session bus of $DBUS_SESSION_BUS_ADDRESS
+func dialSessionBus() (*dbusConn, error) {
+	address := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+	if address == "" {
+		address = fmt.Sprintf("unix:path=/run/user/%d/bus", os.Getuid())
+	}
+
+	var lastErr error = errors.New("dbus: no supported bus address")
+	for _, addr := range strings.Split(address, ";") {
+		path, ok := parseDBusAddress(addr)
+		if !ok {
+			continue
+		}
+		conn, err := net.DialTimeout("unix", path, dbusCallTimeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		c := &dbusConn{
+			conn:    conn,
+			reader:  bufio.NewReader(conn),
+			pending: make(map[uint32]chan *dbusMessage),
+			calls:   make(chan *dbusMessage, 16),
+			done:    make(chan struct{}),
+		}
+		if err := c.auth(); err != nil {
+			conn.Close()
+			lastErr = err
+			continue
+		}
+		go c.readLoop()
+
+		if _, err := c.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", ""); err != nil {
+			c.Close()
+			lastErr = err
+			continue
+		}
+		return c, nil
+	}
+	return nil, lastErr
+}
+
+// Socket path of a "unix:path=..." or "unix:abstract=..." address
+func parseDBusAddress(address string) (string, bool) {
+	transport, params, ok := strings.Cut(address, ":")
+	if !ok || transport != "unix" {
+		return "", false
+	}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(param, "=")
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return "", false
+		}
+		switch key {
+		case "path":
+			return value, true
+		case "abstract":
+			return "@" + value, true
+		}
+	}
+	return "", false
+}
+
+func (c *dbusConn) auth() error {
+	c.conn.SetDeadline(time.Now().Add(dbusCallTimeout))
+	defer c.conn.SetDeadline(time.Time{})
+
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := c.conn.Write([]byte("\x00AUTH EXTERNAL " + uid + "\r\n")); err != nil {
+		return err
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("dbus: authentication rejected: %s", strings.TrimSpace(line))
+	}
+	_, err = c.conn.Write([]byte("BEGIN\r\n"))
+	return err
+}
+
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *dbusConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Call a method and wait for its reply
+func (c *dbusConn) Call(destination string, path dbusObjectPath, iface, member, signature string, args ...interface{}) (*dbusMessage, error) {
+	msg := &dbusMessage{
+		Type:        dbusMethodCall,
+		Serial:      atomic.AddUint32(&c.serial, 1),
+		Path:        path,
+		Interface:   iface,
+		Member:      member,
+		Destination: destination,
+		Signature:   signature,
+		Body:        args,
+	}
+
+	reply := make(chan *dbusMessage, 1)
+	c.mu.Lock()
+	c.pending[msg.Serial] = reply
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, msg.Serial)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(msg); err != nil {
+		return nil, err
+	}
+
+	select {
+	case r := <-reply:
+		if r.Type == dbusError {
+			text := r.ErrorName
+			if len(r.Body) > 0 {
+				if s, ok := r.Body[0].(string); ok {
+					text += ": " + s
+				}
+			}
+			return nil, errors.New(text)
+		}
+		return r, nil
+	case <-c.done:
+		return nil, errors.New("dbus: connection closed")
+	case <-time.After(dbusCallTimeout):
+		return nil, fmt.Errorf("dbus: %s timed out", member)
+	}
+}
+
+// Answer a method call unless the caller doesn't expect a reply
+func (c *dbusConn) Reply(call *dbusMessage, signature string, args ...interface{}) error {
+	if call.Flags&dbusNoReplyExpected != 0 {
+		return nil
+	}
+	return c.send(&dbusMessage{
+		Type:        dbusMethodReturn,
+		Serial:      atomic.AddUint32(&c.serial, 1),
+		ReplySerial: call.Serial,
+		Destination: call.Sender,
+		Signature:   signature,
+		Body:        args,
+	})
+}
+
+func (c *dbusConn) ReplyError(call *dbusMessage, name, text string) error {
+	if call.Flags&dbusNoReplyExpected != 0 {
+		return nil
+	}
+	return c.send(&dbusMessage{
+		Type:        dbusError,
+		Serial:      atomic.AddUint32(&c.serial, 1),
+		ReplySerial: call.Serial,
+		Destination: call.Sender,
+		ErrorName:   name,
+		Signature:   "s",
+		Body:        []interface{}{text},
+	})
+}
+
+func (c *dbusConn) Emit(path dbusObjectPath, iface, member, signature string, args ...interface{}) error {
+	return c.send(&dbusMessage{
+		Type:      dbusSignal,
+		Serial:    atomic.AddUint32(&c.serial, 1),
+		Path:      path,
+		Interface: iface,
+		Member:    member,
+		Signature: signature,
+		Body:      args,
+	})
+}
+
+func (c *dbusConn) send(msg *dbusMessage) error {
+	data, err := msg.marshal()
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err = c.conn.Write(data)
+	return err
+}
+
+func (c *dbusConn) readLoop() {
+	defer close(c.calls)
+	defer close(c.done)
+
+	for {
+		msg, err := readDBusMessage(c.reader)
+		if err != nil {
+			c.conn.Close()
+			return
+		}
+
+		switch msg.Type {
+		case dbusMethodReturn, dbusError:
+			c.mu.Lock()
+			reply, ok := c.pending[msg.ReplySerial]
+			c.mu.Unlock()
+			if ok {
+				reply <- msg
+			}
+		case dbusMethodCall:
+			c.calls <- msg
+		}
+	}
+}
+
+func (msg *dbusMessage) marshal() ([]byte, error) {
+	body := &dbusEncoder{}
+	types := dbusSplitSignature(msg.Signature)
+	if len(types) != len(msg.Body) {
+		return nil, fmt.Errorf("dbus: signature %q doesn't match %d arguments", msg.Signature, len(msg.Body))
+	}
+	for i, sig := range types {
+		if err := body.write(sig, msg.Body[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []interface{}
+	field := func(code byte, sig string, value interface{}) {
+		fields = append(fields, []interface{}{code, dbusVariant{sig, value}})
+	}
+	if msg.Path != "" {
+		field(dbusFieldPath, "o", msg.Path)
+	}
+	if msg.Interface != "" {
+		field(dbusFieldInterface, "s", msg.Interface)
+	}
+	if msg.Member != "" {
+		field(dbusFieldMember, "s", msg.Member)
+	}
+	if msg.ErrorName != "" {
+		field(dbusFieldErrorName, "s", msg.ErrorName)
+	}
+	if msg.ReplySerial != 0 {
+		field(dbusFieldReplySerial, "u", msg.ReplySerial)
+	}
+	if msg.Destination != "" {
+		field(dbusFieldDestination, "s", msg.Destination)
+	}
+	if msg.Signature != "" {
+		field(dbusFieldSignature, "g", dbusSignature(msg.Signature))
+	}
+
+	header := &dbusEncoder{}
+	header.write("y", byte('l'))
+	header.write("y", msg.Type)
+	header.write("y", msg.Flags)
+	header.write("y", byte(1))
+	header.write("u", uint32(len(body.buf)))
+	header.write("u", msg.Serial)
+	if err := header.write("a(yv)", fields); err != nil {
+		return nil, err
+	}
+	header.align(8)
+
+	return append(header.buf, body.buf...), nil
+}
+
+func readDBusMessage(r *bufio.Reader) (*dbusMessage, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("dbus: invalid byte order")
+	}
+
+	bodyLen := order.Uint32(fixed[4:])
+	fieldsLen := order.Uint32(fixed[12:])
+	headerLen := 16 + int(fieldsLen)
+	padded := (headerLen + 7) &^ 7
+	if bodyLen > 1<<27 || fieldsLen > 1<<26 {
+		return nil, errors.New("dbus: message too large")
+	}
+
+	data := make([]byte, padded+int(bodyLen))
+	copy(data, fixed)
+	if _, err := io.ReadFull(r, data[16:]); err != nil {
+		return nil, err
+	}
+
+	msg := &dbusMessage{Type: fixed[1], Flags: fixed[2], Serial: order.Uint32(fixed[8:])}
+
+	header := &dbusDecoder{order: order, data: data[:headerLen], pos: 12}
+	fields, err := header.read("a(yv)")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields.([]interface{}) {
+		entry := f.([]interface{})
+		value := entry[1].(dbusVariant).Value
+		switch entry[0].(byte) {
+		case dbusFieldPath:
+			msg.Path, _ = value.(dbusObjectPath)
+		case dbusFieldInterface:
+			msg.Interface, _ = value.(string)
+		case dbusFieldMember:
+			msg.Member, _ = value.(string)
+		case dbusFieldErrorName:
+			msg.ErrorName, _ = value.(string)
+		case dbusFieldReplySerial:
+			msg.ReplySerial, _ = value.(uint32)
+		case dbusFieldDestination:
+			msg.Destination, _ = value.(string)
+		case dbusFieldSender:
+			msg.Sender, _ = value.(string)
+		case dbusFieldSignature:
+			sig, _ := value.(dbusSignature)
+			msg.Signature = string(sig)
+		}
+	}
+
+	body := &dbusDecoder{order: order, data: data[padded:]}
+	for _, sig := range dbusSplitSignature(msg.Signature) {
+		value, err := body.read(sig)
+		if err != nil {
+			return nil, err
+		}
+		msg.Body = append(msg.Body, value)
+	}
+	return msg, nil
+}
+
+// Split a signature into its complete types, e.g. "sa{sv}as" → s, a{sv}, as
+func dbusSplitSignature(sig string) []string {
+	var types []string
+	for sig != "" {
+		n := dbusTypeLength(sig)
+		if n == 0 {
+			return types
+		}
+		types = append(types, sig[:n])
+		sig = sig[n:]
+	}
+	return types
+}
+
+func dbusTypeLength(sig string) int {
+	if sig == "" {
+		return 0
+	}
+	switch sig[0] {
+	case 'a':
+		n := dbusTypeLength(sig[1:])
+		if n == 0 {
+			return 0
+		}
+		return 1 + n
+	case '(', '{':
+		close := byte(')')
+		if sig[0] == '{' {
+			close = '}'
+		}
+		i := 1
+		for i < len(sig) && sig[i] != close {
+			n := dbusTypeLength(sig[i:])
+			if n == 0 {
+				return 0
+			}
+			i += n
+		}
+		if i >= len(sig) {
+			return 0
+		}
+		return i + 1
+	}
+	return 1
+}
+
+func dbusAlignment(sig string) int {
+	switch sig[0] {
+	case 'n', 'q':
+		return 2
+	case 'b', 'i', 'u', 'h', 's', 'o', 'a':
+		return 4
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 1
+}
+
+// Messages are always sent little-endian
+type dbusEncoder struct {
+	buf []byte
+}
+
+func (e *dbusEncoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *dbusEncoder) write(sig string, v interface{}) error {
+	e.align(dbusAlignment(sig))
+	mismatch := fmt.Errorf("dbus: can't marshal %T as %q", v, sig)
+
+	switch sig[0] {
+	case 'y':
+		b, ok := v.(byte)
+		if !ok {
+			return mismatch
+		}
+		e.buf = append(e.buf, b)
+	case 'b':
+		b, ok := v.(bool)
+		if !ok {
+			return mismatch
+		}
+		var n uint32
+		if b {
+			n = 1
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, n)
+	case 'i':
+		n, ok := v.(int32)
+		if !ok {
+			return mismatch
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(n))
+	case 'u':
+		n, ok := v.(uint32)
+		if !ok {
+			return mismatch
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, n)
+	case 'x':
+		n, ok := v.(int64)
+		if !ok {
+			return mismatch
+		}
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, uint64(n))
+	case 'd':
+		f, ok := v.(float64)
+		if !ok {
+			return mismatch
+		}
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(f))
+	case 's', 'o':
+		var s string
+		switch t := v.(type) {
+		case string:
+			s = t
+		case dbusObjectPath:
+			s = string(t)
+		default:
+			return mismatch
+		}
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(len(s)))
+		e.buf = append(e.buf, s...)
+		e.buf = append(e.buf, 0)
+	case 'g':
+		s, ok := v.(dbusSignature)
+		if !ok {
+			return mismatch
+		}
+		e.buf = append(e.buf, byte(len(s)))
+		e.buf = append(e.buf, s...)
+		e.buf = append(e.buf, 0)
+	case 'v':
+		variant, ok := v.(dbusVariant)
+		if !ok {
+			return mismatch
+		}
+		if err := e.write("g", dbusSignature(variant.Signature)); err != nil {
+			return err
+		}
+		return e.write(variant.Signature, variant.Value)
+	case 'a':
+		return e.writeArray(sig[1:], v, mismatch)
+	case '(':
+		fields, ok := v.([]interface{})
+		types := dbusSplitSignature(sig[1 : len(sig)-1])
+		if !ok || len(fields) != len(types) {
+			return mismatch
+		}
+		for i, t := range types {
+			if err := e.write(t, fields[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		return mismatch
+	}
+	return nil
+}
+
+func (e *dbusEncoder) writeArray(elem string, v interface{}, mismatch error) error {
+	lengthPos := len(e.buf)
+	e.buf = append(e.buf, 0, 0, 0, 0)
+	e.align(dbusAlignment(elem))
+	start := len(e.buf)
+
+	switch t := v.(type) {
+	case []string:
+		for _, s := range t {
+			if err := e.write(elem, s); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range t {
+			if err := e.write(elem, item); err != nil {
+				return err
+			}
+		}
+	case map[string]dbusVariant:
+		if elem != "{sv}" {
+			return mismatch
+		}
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			e.align(8)
+			e.write("s", key)
+			if err := e.write("v", t[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return mismatch
+	}
+
+	binary.LittleEndian.PutUint32(e.buf[lengthPos:], uint32(len(e.buf)-start))
+	return nil
+}
+
+type dbusDecoder struct {
+	order binary.ByteOrder
+	data  []byte
+	pos   int
+}
+
+var errDBusShort = errors.New("dbus: message too short")
+
+func (d *dbusDecoder) align(n int) {
+	d.pos = (d.pos + n - 1) / n * n
+}
+
+func (d *dbusDecoder) take(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, errDBusShort
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// Read one complete type. Basic types become the matching Go types,
+// variants dbusVariant, arrays, structs and dict entries []interface{}.
+func (d *dbusDecoder) read(sig string) (interface{}, error) {
+	d.align(dbusAlignment(sig))
+
+	switch sig[0] {
+	case 'y':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'n', 'q':
+		b, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		if sig[0] == 'n' {
+			return int16(d.order.Uint16(b)), nil
+		}
+		return d.order.Uint16(b), nil
+	case 'b', 'i', 'u', 'h':
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		n := d.order.Uint32(b)
+		switch sig[0] {
+		case 'b':
+			return n != 0, nil
+		case 'i':
+			return int32(n), nil
+		}
+		return n, nil
+	case 'x', 't', 'd':
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		n := d.order.Uint64(b)
+		switch sig[0] {
+		case 'x':
+			return int64(n), nil
+		case 'd':
+			return math.Float64frombits(n), nil
+		}
+		return n, nil
+	case 's', 'o':
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.take(int(d.order.Uint32(b)) + 1)
+		if err != nil {
+			return nil, err
+		}
+		if sig[0] == 'o' {
+			return dbusObjectPath(s[:len(s)-1]), nil
+		}
+		return string(s[:len(s)-1]), nil
+	case 'g':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.take(int(b[0]) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return dbusSignature(s[:len(s)-1]), nil
+	case 'v':
+		s, err := d.read("g")
+		if err != nil {
+			return nil, err
+		}
+		inner := string(s.(dbusSignature))
+		if dbusTypeLength(inner) != len(inner) || inner == "" {
+			return nil, fmt.Errorf("dbus: invalid variant signature %q", inner)
+		}
+		value, err := d.read(inner)
+		if err != nil {
+			return nil, err
+		}
+		return dbusVariant{inner, value}, nil
+	case 'a':
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		length := int(d.order.Uint32(b))
+		d.align(dbusAlignment(sig[1:]))
+		end := d.pos + length
+		if end > len(d.data) {
+			return nil, errDBusShort
+		}
+		items := []interface{}{}
+		for d.pos < end {
+			item, err := d.read(sig[1:])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case '(', '{':
+		var fields []interface{}
+		for _, t := range dbusSplitSignature(sig[1 : len(sig)-1]) {
+			field, err := d.read(t)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("dbus: unsupported type %q", sig)
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDBusMarshal(t *testing.T) {
+	metadata := map[string]dbusVariant{
+		"xesam:title":  {"s", "Song"},
+		"xesam:artist": {"as", []string{"Artist"}},
+		"volume":       {"d", 0.25},
+	}
+	msg := &dbusMessage{
+		Type:        dbusMethodCall,
+		Flags:       dbusNoReplyExpected,
+		Serial:      7,
+		Path:        mprisPath,
+		Interface:   dbusPropertiesIface,
+		Member:      "Set",
+		Destination: mprisBusName,
+		Signature:   "yxbisogva{sv}as(yx)",
+		Body: []interface{}{
+			byte(3), int64(-5), true, int32(-2), "text", mprisNoTrack, dbusSignature("a{sv}"),
+			dbusVariant{"u", uint32(42)}, metadata, []string{"a", "bc"},
+			[]interface{}{byte(1), int64(1 << 40)},
+		},
+	}
+	data, err := msg.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readDBusMessage(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Type != msg.Type || got.Flags != msg.Flags || got.Serial != msg.Serial || got.Path != msg.Path ||
+		got.Interface != msg.Interface || got.Member != msg.Member || got.Destination != msg.Destination ||
+		got.Signature != msg.Signature {
+		t.Errorf("header = %+v", got)
+	}
+
+	// Arrays, structs and dict entries come back as []interface{}, dicts
+	// sorted by key
+	want := []interface{}{
+		byte(3), int64(-5), true, int32(-2), "text", mprisNoTrack, dbusSignature("a{sv}"),
+		dbusVariant{"u", uint32(42)},
+		[]interface{}{
+			[]interface{}{"volume", dbusVariant{"d", 0.25}},
+			[]interface{}{"xesam:artist", dbusVariant{"as", []interface{}{"Artist"}}},
+			[]interface{}{"xesam:title", dbusVariant{"s", "Song"}},
+		},
+		[]interface{}{"a", "bc"},
+		[]interface{}{byte(1), int64(1 << 40)},
+	}
+	if !reflect.DeepEqual(got.Body, want) {
+		t.Errorf("body = %#v\nwant %#v", got.Body, want)
+	}
+}
+
+func TestDBusAlignment(t *testing.T) {
+	tests := []struct {
+		sig  string
+		args []interface{}
+		want []byte
+	}{
+		// int64 aligned to 8
+		{"yx", []interface{}{byte(1), int64(2)}, []byte{
+			1, 0, 0, 0, 0, 0, 0, 0,
+			2, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		// The array length excludes the padding before the first dict
+		// entry, which starts at 8
+		{"ya{sv}", []interface{}{byte(1), map[string]dbusVariant{"k": {"u", uint32(5)}}}, []byte{
+			1, 0, 0, 0, 16, 0, 0, 0,
+			1, 0, 0, 0, 'k', 0, 1, 'u',
+			0, 0, 0, 0, 5, 0, 0, 0,
+		}},
+		// Empty arrays still pad to their element's alignment
+		{"ya(x)", []interface{}{byte(1), []interface{}{}}, []byte{
+			1, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		{"sg", []interface{}{"ab", dbusSignature("as")}, []byte{
+			2, 0, 0, 0, 'a', 'b', 0, 2, 'a', 's', 0,
+		}},
+	}
+	for _, test := range tests {
+		data, err := (&dbusMessage{Type: dbusSignal, Serial: 1, Signature: test.sig, Body: test.args}).marshal()
+		if err != nil {
+			t.Fatalf("%s: %v", test.sig, err)
+		}
+		if (len(data)-len(test.want))%8 != 0 {
+			t.Errorf("%s: header not padded to 8", test.sig)
+		}
+		if body := data[len(data)-len(test.want):]; !bytes.Equal(body, test.want) {
+			t.Errorf("%s: body = %v, want %v", test.sig, body, test.want)
+		}
+
+		got, err := readDBusMessage(bufio.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("%s: %v", test.sig, err)
+		}
+		if len(got.Body) != len(test.args) {
+			t.Errorf("%s: read %d values", test.sig, len(got.Body))
+		}
+	}
+
+	if _, err := (&dbusMessage{Signature: "su", Body: []interface{}{"a", 1}}).marshal(); err == nil {
+		t.Error("int marshalled as uint32")
+	}
+}
+
+// Private session bus for the test, skipped without dbus-daemon
+func startTestBus(t *testing.T) {
+	t.Helper()
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not installed")
+	}
+
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "bus")
+	config := filepath.Join(dir, "session.conf")
+	os.WriteFile(config, []byte(`<busconfig>
+  <type>session</type>
+  <listen>unix:path=`+socket+`</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>`), 0o644)
+
+	cmd := exec.Command(daemon, "--config-file="+config, "--nofork")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	for start := time.Now(); ; time.Sleep(20 * time.Millisecond) {
+		if _, err := os.Stat(socket); err == nil {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("dbus-daemon didn't start")
+		}
+	}
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path="+socket)
+}
+
+// BluOS player double with long-polling status and a /Skip that waits for
+// the test
+type bluosStub struct {
+	mu      sync.Mutex
+	etag    int
+	changed chan struct{}
+	state   string
+	song    int
+	volume  int
+
+	skip chan struct{}
+}
+
+func (s *bluosStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/Status":
+		s.mu.Lock()
+		etag, changed := s.etag, s.changed
+		s.mu.Unlock()
+		if r.URL.Query().Get("etag") == strconv.Itoa(etag) {
+			select {
+			case <-changed:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		s.mu.Lock()
+		fmt.Fprintf(w, `<status etag="%d"><state>%s</state><song>Song %d</song><artist>Artist</artist><album>Album</album><volume>%d</volume><image>/Artwork?id=%d</image></status>`,
+			s.etag, s.state, s.song, s.volume, s.song)
+		s.mu.Unlock()
+	case "/SyncStatus":
+		if r.URL.Query().Get("etag") == "1" {
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `<SyncStatus etag="1"/>`)
+	case "/Play":
+		s.update(func() { s.state = "play" })
+	case "/Pause":
+		s.update(func() { s.state = "pause" })
+	case "/Skip":
+		<-s.skip
+		s.update(func() { s.song++ })
+	case "/Volume":
+		level, _ := strconv.Atoi(r.URL.Query().Get("level"))
+		s.update(func() { s.volume = level })
+	default:
+		http.NotFound(w, r)
+		return
+	}
+}
+
+func (s *bluosStub) update(change func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change()
+	s.etag++
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func TestMPRIS(t *testing.T) {
+	startTestBus(t)
+
+	stub := &bluosStub{etag: 1, changed: make(chan struct{}), state: "stop", song: 1, volume: 20, skip: make(chan struct{})}
+	player := httptest.NewServer(stub)
+	defer player.Close()
+	defer close(stub.skip)
+
+	startMPRIS()
+	if mpris == nil {
+		t.Fatal("MPRIS service not started")
+	}
+	defer func() {
+		mprisSetPlayer(nil, PlayerInfo{})
+		mpris.conn.Close()
+		mpris = nil
+	}()
+
+	client := NewBluesoundClient("127.0.0.1")
+	client.baseURL = player.URL
+	mprisSetPlayer(client, PlayerInfo{IP: "127.0.0.1", Name: "Kitchen", Type: DeviceTypeBluOS})
+
+	bus, err := dialSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bus.Close()
+
+	call := func(iface, member, signature string, args ...interface{}) []interface{} {
+		t.Helper()
+		reply, err := bus.Call(mprisBusName, mprisPath, iface, member, signature, args...)
+		if err != nil {
+			t.Fatalf("%s.%s: %v", iface, member, err)
+		}
+		return reply.Body
+	}
+	property := func(iface, name string) interface{} {
+		t.Helper()
+		return call(dbusPropertiesIface, "Get", "ss", iface, name)[0].(dbusVariant).Value
+	}
+	metadata := func() map[string]interface{} {
+		t.Helper()
+		fields := map[string]interface{}{}
+		for _, entry := range property(mprisPlayerIface, "Metadata").([]interface{}) {
+			pair := entry.([]interface{})
+			fields[pair[0].(string)] = pair[1].(dbusVariant).Value
+		}
+		return fields
+	}
+	// Status updates reach the service asynchronously
+	waitFor := func(name string, get func() interface{}, want interface{}) {
+		t.Helper()
+		var got interface{}
+		for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(20 * time.Millisecond) {
+			if got = get(); reflect.DeepEqual(got, want) {
+				return
+			}
+		}
+		t.Fatalf("%s = %#v, want %#v", name, got, want)
+	}
+	playback := func() interface{} { return property(mprisPlayerIface, "PlaybackStatus") }
+	volume := func() interface{} { return property(mprisPlayerIface, "Volume") }
+	title := func() interface{} { return metadata()["xesam:title"] }
+
+	if identity := property(mprisRootIface, "Identity"); identity != "Multi-Room Player: Kitchen" {
+		t.Errorf("Identity = %q", identity)
+	}
+	waitFor("PlaybackStatus", playback, "Stopped")
+	waitFor("Volume", volume, 0.2)
+
+	fields := metadata()
+	if fields["xesam:title"] != "Song 1" || !reflect.DeepEqual(fields["xesam:artist"], []interface{}{"Artist"}) ||
+		fields["mpris:artUrl"] != "http://127.0.0.1:11000/Artwork?id=1" {
+		t.Errorf("Metadata = %v", fields)
+	}
+	if _, ok := fields["mpris:trackid"].(dbusObjectPath); !ok {
+		t.Errorf("mpris:trackid = %#v", fields["mpris:trackid"])
+	}
+
+	all := call(dbusPropertiesIface, "GetAll", "s", mprisPlayerIface)[0].([]interface{})
+	if len(all) != 13 {
+		t.Errorf("GetAll returned %d properties", len(all))
+	}
+
+	call(mprisPlayerIface, "Play", "")
+	waitFor("PlaybackStatus", playback, "Playing")
+	call(mprisPlayerIface, "PlayPause", "")
+	waitFor("PlaybackStatus", playback, "Paused")
+	call(dbusPropertiesIface, "Set", "ssv", mprisPlayerIface, "Volume", dbusVariant{"d", 0.55})
+	waitFor("Volume", volume, 0.55)
+
+	if _, err := bus.Call(mprisBusName, mprisPath, dbusPropertiesIface, "Set", "ssv", mprisPlayerIface, "PlaybackStatus", dbusVariant{"s", "Playing"}); err == nil {
+		t.Error("read-only property was set")
+	}
+	if _, err := bus.Call(mprisBusName, mprisPath, mprisPlayerIface, "Fly", ""); err == nil {
+		t.Error("unknown method succeeded")
+	}
+
+	// While the player takes its time with Next, other calls are answered
+	next := make(chan error, 1)
+	go func() {
+		_, err := bus.Call(mprisBusName, mprisPath, mprisPlayerIface, "Next", "")
+		next <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	call(dbusPeerIface, "Ping", "")
+	if property(mprisPlayerIface, "CanGoNext") != true || time.Since(start) > time.Second {
+		t.Error("calls blocked by a pending player action")
+	}
+	select {
+	case err := <-next:
+		t.Fatalf("Next returned before the player answered: %v", err)
+	default:
+	}
+	stub.skip <- struct{}{}
+	if err := <-next; err != nil {
+		t.Fatal(err)
+	}
+	waitFor("xesam:title", title, "Song 2")
+}
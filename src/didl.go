@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// DIDL-Lite documents as returned by Sonos ContentDirectory Browse/Search
+type DIDLLite struct {
+	XMLName    xml.Name     `xml:"DIDL-Lite"`
+	Items      []DIDLObject `xml:"item"`
+	Containers []DIDLObject `xml:"container"`
+}
+
+type DIDLObject struct {
+	ID          string `xml:"id,attr"`
+	ParentID    string `xml:"parentID,attr"`
+	Title       string `xml:"title"`
+	Creator     string `xml:"creator"`
+	Album       string `xml:"album"`
+	Class       string `xml:"class"`
+	AlbumArtURI string `xml:"albumArtURI"`
+	Res         string `xml:"res"`
+	ResMD       string `xml:"resMD"`
+	Description string `xml:"description"`
+	Desc        string `xml:"desc"`
+}
+
+func parseDIDL(didl string) (*DIDLLite, error) {
+	var doc DIDLLite
+	if strings.TrimSpace(didl) == "" {
+		return &doc, nil
+	}
+	if err := xml.Unmarshal([]byte(didl), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse DIDL-Lite: %w", err)
+	}
+	return &doc, nil
+}
+
+// Build a single-item DIDL-Lite document (unescaped)
+func buildDIDLMetadata(id, title, class, desc string) string {
+	return buildDIDL("item", id, "-1", title, class, desc)
+}
+
+// Rebuild the metadata Sonos needs to play or enqueue a browsed object
+func didlForObject(obj DIDLObject) string {
+	element := "item"
+	if strings.HasPrefix(obj.Class, "object.container") {
+		element = "container"
+	}
+	return buildDIDL(element, obj.ID, obj.ParentID, obj.Title, obj.Class, obj.Desc)
+}
+
+func buildDIDL(element, id, parentID, title, class, desc string) string {
+	var sb strings.Builder
+	sb.WriteString(`<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">`)
+	sb.WriteString(fmt.Sprintf(`<%s id="%s" parentID="%s" restricted="true">`, element, html.EscapeString(id), html.EscapeString(parentID)))
+	sb.WriteString(fmt.Sprintf(`<dc:title>%s</dc:title>`, html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf(`<upnp:class>%s</upnp:class>`, class))
+	if desc != "" {
+		sb.WriteString(fmt.Sprintf(`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">%s</desc>`, html.EscapeString(desc)))
+	}
+	sb.WriteString(fmt.Sprintf(`</%s></DIDL-Lite>`, element))
+	return sb.String()
+}
+
+// Favorite entry for ContentDirectory CreateObject in FV:2. The playable
+// item's own DIDL goes into r:resMD, just like favorites created by the app.
+func buildFavoriteDIDL(title, uri, meta, image string) string {
+	protocol := "x-rincon-mp3radio"
+	if idx := strings.Index(uri, ":"); idx != -1 {
+		protocol = uri[:idx]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">`)
+	sb.WriteString(`<item id="" parentID="FV:2" restricted="false">`)
+	sb.WriteString(fmt.Sprintf(`<dc:title>%s</dc:title>`, html.EscapeString(title)))
+	sb.WriteString(`<upnp:class>object.itemobject.item.sonos-favorite</upnp:class>`)
+	if image != "" {
+		sb.WriteString(fmt.Sprintf(`<upnp:albumArtURI>%s</upnp:albumArtURI>`, html.EscapeString(image)))
+	}
+	sb.WriteString(fmt.Sprintf(`<res protocolInfo="%s:*:*:*">%s</res>`, html.EscapeString(protocol), html.EscapeString(uri)))
+	sb.WriteString(`<r:type>instantPlay</r:type>`)
+	sb.WriteString(fmt.Sprintf(`<r:resMD>%s</r:resMD>`, html.EscapeString(meta)))
+	sb.WriteString(`</item></DIDL-Lite>`)
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Change of a player pushed to event subscribers (e.g. WebSocket clients).
+// Type is "status", "track", "volume", "topology" or "players".
+type PlayerEvent struct {
+	Type   string    `json:"type"`
+	Player string    `json:"player,omitempty"`
+	IP     string    `json:"ip,omitempty"`
+	Status *Status   `json:"status,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Fan-out of player events; slow subscribers lose events instead of
+// blocking the publishers
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan PlayerEvent]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[chan PlayerEvent]struct{})}
+}
+
+func (b *EventBus) Subscribe() chan PlayerEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan PlayerEvent, 32)
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (b *EventBus) Unsubscribe(ch chan PlayerEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (b *EventBus) Publish(event PlayerEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Event types for the differences between two statuses of a player
+func statusChanges(old, current *Status) []string {
+	if old == nil {
+		return []string{"status"}
+	}
+
+	var changes []string
+	if old.State != current.State {
+		changes = append(changes, "status")
+	}
+	if old.Song != current.Song || old.Artist != current.Artist || old.Album != current.Album ||
+		old.StreamURL != current.StreamURL || old.Image != current.Image {
+		changes = append(changes, "track")
+	}
+	if old.Volume != current.Volume {
+		changes = append(changes, "volume")
+	}
+	return changes
+}
+
+// How often players without an event source are polled
+const statusPollInterval = 5 * time.Second
+
+// Fallback for clients that can't push changes
+func pollStatus(client AudioClient, stop <-chan struct{}, onStatus func(*Status)) {
+	ticker := time.NewTicker(statusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if status, err := client.GetStatus(); err == nil {
+			onStatus(status)
+		}
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Play state independent of the device: "playing", "paused" or "stopped".
+// BluOS reports play/stream/pause/stop, Sonos playing/paused_playback/stopped.
+func playState(status *Status) string {
+	state := strings.ToLower(status.State)
+	switch {
+	case strings.HasPrefix(state, "play"), state == "stream":
+		return "playing"
+	case strings.HasPrefix(state, "pause"):
+		return "paused"
+	}
+	return "stopped"
+}
+
+// Sonos is TRANSITIONING between tracks and BluOS connecting while a stream
+// starts; neither says whether the player plays or stopped
+func transitionalState(status *Status) bool {
+	switch strings.ToLower(status.State) {
+	case "transitioning", "connecting":
+		return true
+	}
+	return false
+}
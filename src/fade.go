@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Volume fades step SetVolume on any player. Players implementing
+// VolumeRamper ramp natively instead.
+
+type FadeCurve string
+
+const (
+	FadeLinear FadeCurve = "linear"
+	// Eases in and out (smoothstep), so start and end are less abrupt
+	FadeCurved FadeCurve = "curved"
+)
+
+// Interval between volume steps of an app-side fade
+const fadeStepInterval = 500 * time.Millisecond
+
+type Fade struct {
+	Target   int
+	Duration time.Duration
+	Curve    FadeCurve
+}
+
+// A running fade; done is closed when it has finished or was cancelled
+type fadeJob struct {
+	client    AudioClient
+	fade      Fade
+	cancel    chan struct{}
+	done      chan struct{}
+	cancelled bool
+	err       error
+}
+
+// Running fades by player, so fades in different rooms don't interfere
+var (
+	fadeMu      sync.Mutex
+	activeFades = make(map[string]*fadeJob)
+)
+
+// Clients of the same player share its fade
+func fadeKey(client AudioClient) string {
+	switch c := client.(type) {
+	case *BluesoundClient:
+		return c.baseURL
+	case *SonosClient:
+		return c.baseURL
+	}
+	return fmt.Sprintf("%p", client)
+}
+
+// Start a fade in the background, replacing any fade that is still running
+// on the player
+func startFade(client AudioClient, fade Fade) *fadeJob {
+	job := &fadeJob{
+		client: client,
+		fade:   fade,
+		cancel: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	key := fadeKey(client)
+
+	fadeMu.Lock()
+	if running, ok := activeFades[key]; ok {
+		close(running.cancel)
+	}
+	activeFades[key] = job
+	fadeMu.Unlock()
+
+	go func() {
+		job.err = job.run()
+		fadeMu.Lock()
+		if activeFades[key] == job {
+			delete(activeFades, key)
+		}
+		fadeMu.Unlock()
+		close(job.done)
+	}()
+	return job
+}
+
+// Cancel the player's running fade, e.g. because the volume was set manually
+func cancelFade(client AudioClient) {
+	fadeMu.Lock()
+	defer fadeMu.Unlock()
+
+	key := fadeKey(client)
+	if running, ok := activeFades[key]; ok {
+		close(running.cancel)
+		delete(activeFades, key)
+	}
+}
+
+// Wait for the fade and report whether it ran to completion
+func (j *fadeJob) Wait() bool {
+	<-j.done
+	return !j.cancelled && j.err == nil
+}
+
+func (j *fadeJob) run() error {
+	// The native ramp has its own speed, so it only fits matching requests
+	if ramper, ok := j.client.(VolumeRamper); ok && j.fade.Curve == FadeLinear && rampMatches(ramper.RampDuration(), j.fade.Duration) {
+		rampTime, err := ramper.RampToVolume(j.fade.Target)
+		if err == nil {
+			return j.sleep(rampTime)
+		}
+		// Fall back to stepping the volume ourselves
+	}
+
+	status, err := j.client.GetStatus()
+	if err != nil {
+		return err
+	}
+	start := status.Volume
+
+	steps := int(j.fade.Duration / fadeStepInterval)
+	if steps < 1 {
+		steps = 1
+	}
+
+	last := start
+	for i := 1; i <= steps; i++ {
+		if err := j.sleep(j.fade.Duration / time.Duration(steps)); err != nil {
+			return err
+		}
+
+		// Someone else changed the volume: leave it alone
+		if current, err := j.client.GetStatus(); err == nil && current.Volume != last {
+			j.cancelled = true
+			return nil
+		}
+
+		level := start + int(float64(j.fade.Target-start)*j.fade.Curve.apply(float64(i)/float64(steps)))
+		if err := j.client.SetVolume(level); err != nil {
+			return err
+		}
+		last = level
+	}
+	return nil
+}
+
+// Whether a native ramp is close enough to the requested fade duration
+func rampMatches(ramp, requested time.Duration) bool {
+	diff := ramp - requested
+	return max(diff, -diff) <= requested/10
+}
+
+// Sleep unless the fade is cancelled first
+func (j *fadeJob) sleep(d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-j.cancel:
+		j.cancelled = true
+		return fmt.Errorf("fade cancelled")
+	}
+}
+
+func (c FadeCurve) apply(progress float64) float64 {
+	if c == FadeCurved {
+		return progress * progress * (3 - 2*progress)
+	}
+	return progress
+}
+
+func parseFadeCurve(arg string) (FadeCurve, bool) {
+	switch FadeCurve(strings.ToLower(arg)) {
+	case FadeLinear:
+		return FadeLinear, true
+	case FadeCurved:
+		return FadeCurved, true
+	}
+	return "", false
+}
+
+// Fade out, stop and restore the original volume for the next start. The
+// returned channel reports whether playback was stopped (false if the fade
+// was cancelled).
+func fadeOutAndStop(client AudioClient, d time.Duration) <-chan bool {
+	startVolume := -1
+	if status, err := client.GetStatus(); err == nil {
+		startVolume = status.Volume
+	}
+
+	stopped := make(chan bool, 1)
+	job := startFade(client, Fade{Target: 0, Duration: d, Curve: FadeCurved})
+	go func() {
+		defer close(stopped)
+		if !job.Wait() {
+			stopped <- false
+			return
+		}
+		client.Stop()
+		if startVolume > 0 {
+			client.SetVolume(startVolume)
+		}
+		stopped <- true
+	}()
+	return stopped
+}
+
+// Extract a "--fade <duration>" option from command arguments
+func fadeOption(args []string) (time.Duration, []string, bool) {
+	var rest []string
+	var d time.Duration
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--fade" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			return 0, nil, false
+		}
+		parsed, err := time.ParseDuration(args[i+1])
+		if err != nil || parsed <= 0 {
+			return 0, nil, false
+		}
+		d = parsed
+		i++
+	}
+	return d, rest, true
+}
+
+// Handle "fade <0-100> <duration> [linear|curved]"
+func handleFadeCommand(args []string) {
+	if len(args) == 1 && strings.ToLower(args[0]) == "stop" {
+		cancelFade(tuiState.client)
+		tuiState.lastAction = getText("fade_cancelled")
+		return
+	}
+	if len(args) < 2 {
+		reportUsage(getText("fade_usage"))
+		return
+	}
+
+	target, err := strconv.Atoi(args[0])
+	if err != nil || target < 0 || target > 100 {
+		reportUsage(getText("invalid_volume"))
+		return
+	}
+	d, err := time.ParseDuration(args[1])
+	if err != nil || d <= 0 {
+		reportUsage(getText("fade_usage"))
+		return
+	}
+	curve := FadeLinear
+	if len(args) > 2 {
+		var ok bool
+		if curve, ok = parseFadeCurve(args[2]); !ok {
+			reportUsage(getText("fade_usage"))
+			return
+		}
+	}
+
+	startFade(tuiState.client, Fade{Target: target, Duration: d, Curve: curve})
+	tuiState.lastAction = fmt.Sprintf(getText("fading_volume"), target, d)
+}
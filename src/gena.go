@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Receiver for UPnP event notifications (GENA) from Sonos players. Players
+// send NOTIFY requests to our callback URL; every subscription gets a path of
+// its own to dispatch them by. Its SID can't be used for that: players send
+// the first NOTIFY right after answering the SUBSCRIBE, before we may have
+// read the SID.
+type genaListener struct {
+	port string
+
+	mu       sync.Mutex
+	handlers map[string]func() // by callback path
+	lastID   int
+}
+
+// Path of the callback URL on the API server
+const genaPath = "/gena/"
+
+// Set while the API server runs; without it Sonos clients fall back to polling
+var genaEvents *genaListener
+
+func newGENAListener(port string) *genaListener {
+	return &genaListener{port: port, handlers: make(map[string]func())}
+}
+
+func (l *genaListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "NOTIFY" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// The body (LastChange etc.) isn't needed, handlers fetch the new state
+	io.Copy(io.Discard, r.Body)
+
+	l.mu.Lock()
+	handler, ok := l.handlers[r.URL.Path]
+	l.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	go handler()
+	w.WriteHeader(http.StatusOK)
+}
+
+// Register a handler before subscribing; returns its callback path
+func (l *genaListener) handle(handler func()) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastID++
+	path := fmt.Sprintf("%s%d", genaPath, l.lastID)
+	l.handlers[path] = handler
+	return path
+}
+
+func (l *genaListener) remove(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.handlers, path)
+}
+
+// Callback URLs start with the local address the player can reach us on
+func (l *genaListener) callbackBase(playerIP string) (string, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(playerIP, SonosPort))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	local := conn.LocalAddr().(*net.UDPAddr).IP
+	return "http://" + net.JoinHostPort(local.String(), l.port), nil
+}
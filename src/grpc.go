@@ -0,0 +1,461 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// gRPC API of the daemon (`daemon --grpc 127.0.0.1:50051`) as defined in
+// proto/bluesoundplayer/v1/player.proto. gRPC runs over unencrypted HTTP/2
+// (h2c) and the messages are encoded by hand, so no generated code is needed
+// on this side.
+
+const grpcServicePath = "/bluesoundplayer.v1.Player/"
+
+// Largest request message accepted
+const grpcMaxMessageSize = 4 << 20
+
+// Status codes
+const (
+	grpcOK              = 0
+	grpcInvalidArgument = 3
+	grpcNotFound        = 5
+	grpcUnimplemented   = 12
+	grpcInternal        = 13
+	grpcUnavailable     = 14
+)
+
+type grpcError struct {
+	code    int
+	message string
+}
+
+func (e *grpcError) Error() string {
+	return e.message
+}
+
+func grpcErrorf(code int, format string, args ...interface{}) error {
+	return &grpcError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+type grpcServer struct {
+	api *apiServer
+}
+
+type grpcHandler func(req protoFields) ([]byte, error)
+
+func serveGRPC(addr string, api *apiServer) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC address %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		warnUnauthenticated("gRPC API", addr)
+	}
+
+	server := &http.Server{Addr: addr, Handler: &grpcServer{api: api}}
+	server.Protocols = new(http.Protocols)
+	server.Protocols.SetUnencryptedHTTP2(true)
+
+	log.Printf("serving gRPC on %s", addr)
+	return server.ListenAndServe()
+}
+
+func (g *grpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+
+	method, _ := strings.CutPrefix(r.URL.Path, grpcServicePath)
+	req, err := readGRPCMessage(r.Body)
+	if err != nil {
+		writeGRPCStatus(w, err)
+		return
+	}
+
+	if method == "Watch" {
+		writeGRPCStatus(w, g.watch(w, r, req))
+		return
+	}
+
+	handler, ok := g.handlers()[method]
+	if !ok {
+		writeGRPCStatus(w, grpcErrorf(grpcUnimplemented, "unknown method %s", r.URL.Path))
+		return
+	}
+	resp, err := handler(req)
+	if err == nil {
+		err = writeGRPCMessage(w, resp)
+	}
+	writeGRPCStatus(w, err)
+}
+
+// Length-prefixed message of a request; compression is never negotiated
+func readGRPCMessage(r io.Reader) (protoFields, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "missing request message")
+	}
+	if prefix[0] != 0 {
+		return nil, grpcErrorf(grpcUnimplemented, "compressed messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if length > grpcMaxMessageSize {
+		return nil, grpcErrorf(grpcInvalidArgument, "request message too large")
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "truncated request message")
+	}
+	fields, err := parseProto(data)
+	if err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	return fields, nil
+}
+
+func writeGRPCMessage(w http.ResponseWriter, msg []byte) error {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	if _, err := w.Write(append(frame, msg...)); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+func writeGRPCStatus(w http.ResponseWriter, err error) {
+	code, message := grpcOK, ""
+	if err != nil {
+		var gerr *grpcError
+		if errors.As(err, &gerr) {
+			code, message = gerr.code, gerr.message
+		} else {
+			code, message = grpcInternal, err.Error()
+		}
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set("Grpc-Message", grpcPercentEncode(message))
+	}
+}
+
+// Bytes outside printable ASCII and '%' are percent-encoded in Grpc-Message
+func grpcPercentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func (g *grpcServer) handlers() map[string]grpcHandler {
+	return map[string]grpcHandler{
+		"ListPlayers": func(protoFields) ([]byte, error) {
+			return encodePlayerList(g.api.knownPlayers()), nil
+		},
+		"Rescan": func(protoFields) ([]byte, error) {
+			if err := g.api.rescan(); err != nil {
+				return nil, grpcErrorf(grpcUnavailable, "%v", err)
+			}
+			return encodePlayerList(g.api.knownPlayers()), nil
+		},
+
+		"GetStatus": g.control(func(client AudioClient, req protoFields) error { return nil }),
+		"GetPresets": g.query(func(client AudioClient, req protoFields) ([]byte, error) {
+			presets, err := client.GetPresets()
+			return encodePresetList(presets), err
+		}),
+		"PlayPreset": g.control(func(client AudioClient, req protoFields) error {
+			return client.PlayPreset(req.int(2))
+		}),
+		"PlayURL": g.control(func(client AudioClient, req protoFields) error {
+			if req.string(2) == "" {
+				return grpcErrorf(grpcInvalidArgument, "url is required")
+			}
+			return client.PlayURL(req.string(2), req.string(3))
+		}),
+		"Play":     g.control(func(client AudioClient, req protoFields) error { return client.Play() }),
+		"Pause":    g.control(func(client AudioClient, req protoFields) error { return client.Pause() }),
+		"Stop":     g.control(func(client AudioClient, req protoFields) error { return client.Stop() }),
+		"Next":     g.control(func(client AudioClient, req protoFields) error { return client.Next() }),
+		"Previous": g.control(func(client AudioClient, req protoFields) error { return client.Previous() }),
+		"SetVolume": g.control(func(client AudioClient, req protoFields) error {
+			level := req.int(2)
+			if level < 0 || level > 100 {
+				return grpcErrorf(grpcInvalidArgument, "level must be between 0 and 100")
+			}
+			cancelFade(client)
+			return client.SetVolume(level)
+		}),
+
+		"AddSlaves": g.control(func(client AudioClient, req protoFields) error {
+			return g.eachSlave(req, client.AddSlave)
+		}),
+		"RemoveSlaves": g.control(func(client AudioClient, req protoFields) error {
+			return g.eachSlave(req, client.RemoveSlave)
+		}),
+		"Ungroup": g.control(func(client AudioClient, req protoFields) error {
+			if err := client.RemoveAllSlaves(); err != nil {
+				return client.LeaveGroup()
+			}
+			return nil
+		}),
+
+		"GetQueue": g.queue(func(client AudioClient, req protoFields) error { return nil }),
+		"AddToQueue": g.queue(func(client AudioClient, req protoFields) error {
+			return client.AddToQueue(req.string(2), req.string(3), req.bool(4))
+		}),
+		"RemoveFromQueue": g.queue(func(client AudioClient, req protoFields) error {
+			return client.RemoveFromQueue(req.int(2))
+		}),
+		"MoveQueueItem": g.queue(func(client AudioClient, req protoFields) error {
+			return client.MoveQueueItem(req.int(2), req.int(3))
+		}),
+		"ClearQueue": g.queue(func(client AudioClient, req protoFields) error { return client.ClearQueue() }),
+		"PlayQueueItem": g.control(func(client AudioClient, req protoFields) error {
+			return client.PlayQueueItem(req.int(2))
+		}),
+		"SaveQueue": g.query(func(client AudioClient, req protoFields) ([]byte, error) {
+			return nil, client.SaveQueue(req.string(2))
+		}),
+
+		"ListScenes": func(protoFields) ([]byte, error) {
+			scenes, err := loadScenes()
+			if err != nil {
+				return nil, grpcErrorf(grpcInternal, "%v", err)
+			}
+			return encodeSceneList(scenes), nil
+		},
+		"ApplyScene": func(req protoFields) ([]byte, error) {
+			scenes, err := loadScenes()
+			if err != nil {
+				return nil, grpcErrorf(grpcInternal, "%v", err)
+			}
+			scene, ok := findScene(scenes, req.string(1))
+			if !ok {
+				return nil, grpcErrorf(grpcNotFound, "scene %q not found", req.string(1))
+			}
+			g.api.controlMu.Lock()
+			defer g.api.controlMu.Unlock()
+			if err := applyScene(scene, g.api.knownPlayers(), g.api.clientFor); err != nil {
+				return nil, grpcErrorf(grpcUnavailable, "%v", err)
+			}
+			return nil, nil
+		},
+	}
+}
+
+// Client of the request's player (field 1, name or IP)
+func (g *grpcServer) client(req protoFields) (AudioClient, error) {
+	name := req.string(1)
+	player, ok := findPlayer(g.api.knownPlayers(), name)
+	if !ok {
+		return nil, grpcErrorf(grpcNotFound, "player %q not found", name)
+	}
+	client, err := g.api.clientFor(player)
+	if err != nil {
+		return nil, grpcErrorf(grpcInternal, "%v", err)
+	}
+	return client, nil
+}
+
+// Run a request against the player; player errors become Unavailable
+func (g *grpcServer) query(run func(AudioClient, protoFields) ([]byte, error)) grpcHandler {
+	return func(req protoFields) ([]byte, error) {
+		client, err := g.client(req)
+		if err != nil {
+			return nil, err
+		}
+
+		g.api.controlMu.Lock()
+		defer g.api.controlMu.Unlock()
+
+		resp, err := run(client, req)
+		var gerr *grpcError
+		if err != nil && !errors.As(err, &gerr) {
+			err = grpcErrorf(grpcUnavailable, "%v", err)
+		}
+		return resp, err
+	}
+}
+
+// Run an action and answer with the player's new status
+func (g *grpcServer) control(action func(AudioClient, protoFields) error) grpcHandler {
+	return g.query(func(client AudioClient, req protoFields) ([]byte, error) {
+		if err := action(client, req); err != nil {
+			return nil, err
+		}
+		status, err := client.GetStatus()
+		if err != nil {
+			return nil, err
+		}
+		return encodeStatus(status), nil
+	})
+}
+
+// Run a queue action and answer with the queue
+func (g *grpcServer) queue(action func(AudioClient, protoFields) error) grpcHandler {
+	return g.query(func(client AudioClient, req protoFields) ([]byte, error) {
+		if err := action(client, req); err != nil {
+			return nil, err
+		}
+		items, err := client.GetQueue()
+		if err != nil {
+			return nil, err
+		}
+		return encodeQueue(items), nil
+	})
+}
+
+func (g *grpcServer) eachSlave(req protoFields, apply func(slaveIP string) error) error {
+	for _, name := range req.strings(2) {
+		slave, ok := findPlayer(g.api.knownPlayers(), name)
+		if !ok {
+			return grpcErrorf(grpcNotFound, "player %q not found", name)
+		}
+		if err := apply(slave.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stream the current statuses, then every event, until the client leaves
+func (g *grpcServer) watch(w http.ResponseWriter, r *http.Request, req protoFields) error {
+	wanted := make(map[string]bool)
+	for _, name := range req.strings(1) {
+		player, ok := findPlayer(g.api.knownPlayers(), name)
+		if !ok {
+			return grpcErrorf(grpcNotFound, "player %q not found", name)
+		}
+		wanted[player.IP] = true
+	}
+	send := func(event PlayerEvent) error {
+		if len(wanted) > 0 && event.IP != "" && !wanted[event.IP] {
+			return nil
+		}
+		return writeGRPCMessage(w, encodePlayerEvent(event))
+	}
+
+	events := g.api.events.Subscribe()
+	defer g.api.events.Unsubscribe(events)
+
+	for _, event := range g.api.snapshot() {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case event := <-events:
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-r.Context().Done():
+			return nil
+		}
+	}
+}
+
+func encodePlayerList(players []PlayerInfo) []byte {
+	var w protoWriter
+	for _, player := range players {
+		var p protoWriter
+		p.string(1, player.IP)
+		p.string(2, player.Name)
+		p.string(3, player.Brand)
+		p.string(4, player.Model)
+		p.string(5, string(player.Type))
+		w.message(1, p.buf)
+	}
+	return w.buf
+}
+
+func encodeStatus(status *Status) []byte {
+	var w protoWriter
+	w.string(1, status.State)
+	w.string(2, status.Song)
+	w.string(3, status.Artist)
+	w.string(4, status.Album)
+	w.int(5, int64(status.Volume))
+	w.string(6, status.StreamURL)
+	w.string(7, status.Image)
+	return w.buf
+}
+
+func encodePresetList(presets []Preset) []byte {
+	var w protoWriter
+	for _, preset := range presets {
+		var p protoWriter
+		p.int(1, int64(preset.ID))
+		p.string(2, preset.Name)
+		p.string(3, preset.URL)
+		p.string(4, preset.Image)
+		w.message(1, p.buf)
+	}
+	return w.buf
+}
+
+func encodeQueue(items []QueueItem) []byte {
+	var w protoWriter
+	for _, item := range items {
+		var q protoWriter
+		q.int(1, int64(item.Index))
+		q.string(2, item.Title)
+		q.string(3, item.Artist)
+		q.string(4, item.Album)
+		q.string(5, item.URI)
+		w.message(1, q.buf)
+	}
+	return w.buf
+}
+
+func encodeSceneList(scenes []Scene) []byte {
+	var w protoWriter
+	for _, scene := range scenes {
+		var s protoWriter
+		s.string(1, scene.Name)
+		s.strings(2, scene.Group)
+		for _, step := range scene.Players {
+			var p protoWriter
+			p.string(1, step.Player)
+			p.int(2, int64(step.Preset))
+			p.string(3, step.URL)
+			p.string(4, step.Title)
+			p.optionalInt(5, step.Volume)
+			p.bool(6, step.Stop)
+			s.message(3, p.buf)
+		}
+		w.message(1, s.buf)
+	}
+	return w.buf
+}
+
+func encodePlayerEvent(event PlayerEvent) []byte {
+	var w protoWriter
+	w.string(1, event.Type)
+	w.string(2, event.Player)
+	w.string(3, event.IP)
+	if event.Status != nil {
+		w.message(4, encodeStatus(event.Status))
+	}
+	w.int(5, event.Time.UnixMilli())
+	return w.buf
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Play history recorded by the serve and daemon modes: every track a player
+// played, one JSON object per line in history.jsonl, written when the track
+// ends, playback stops or the mode stops.
+type HistoryEntry struct {
+	Player   string    `json:"player"`
+	IP       string    `json:"ip"`
+	Time     time.Time `json:"time"` // when the track started
+	Song     string    `json:"song"`
+	Artist   string    `json:"artist,omitempty"`
+	Album    string    `json:"album,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Listened int       `json:"listened"` // seconds actually played
+}
+
+const (
+	historyFile = "history.jsonl"
+
+	defaultHistoryCount = 10
+	defaultStatsDays    = 7
+	statsTopArtists     = 5
+)
+
+// Track a player is on, with the time it has played so far
+type historyTrack struct {
+	entry        HistoryEntry
+	listened     time.Duration
+	playingSince time.Time // zero while not playing
+	position     int
+	duration     int
+}
+
+func (t *historyTrack) pause(now time.Time) {
+	if !t.playingSince.IsZero() {
+		t.listened += now.Sub(t.playingSince)
+		t.playingSince = time.Time{}
+	}
+}
+
+// Track that ended on a player
+type playedTrack struct {
+	HistoryEntry
+	Position int // last position the player reported, in seconds
+	Duration int // track length in seconds, 0 for streams
+}
+
+func appendHistory(entry HistoryEntry) error {
+	path, err := configPath(historyFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// All entries, oldest first; a missing file is an empty history
+func loadHistory() ([]HistoryEntry, error) {
+	path, err := configPath(historyFile)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		// A line cut short by a crash is skipped, not fatal
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Record the tracks of the server's players until the server stops
+func runHistory(server *apiServer) {
+	watchTracks(server, func(track playedTrack) {
+		if err := appendHistory(track.HistoryEntry); err != nil {
+			log.Printf("could not write play history: %v", err)
+		}
+	})
+}
+
+// Follow the tracks of the server's players and report every track that was
+// played for at least a second once it ends. When the server stops, the
+// tracks still playing end there.
+func watchTracks(server *apiServer, onEnd func(playedTrack)) {
+	events := server.events.Subscribe()
+	defer server.events.Unsubscribe(events)
+
+	tracks := make(map[string]*historyTrack)
+	// Statuses that only move the position publish no event, so it is taken
+	// from the last status the server's watcher got
+	updatePosition := func(ip string) {
+		status := server.lastStatus(ip)
+		if track, ok := tracks[ip]; ok && status != nil && sameTrack(track.entry, status) {
+			track.position = status.Position
+		}
+	}
+	finish := func(ip string, now time.Time) {
+		track, ok := tracks[ip]
+		if !ok {
+			return
+		}
+		updatePosition(ip)
+		delete(tracks, ip)
+		track.pause(now)
+		if track.listened < time.Second {
+			return
+		}
+		track.entry.Listened = int(track.listened.Seconds())
+		onEnd(playedTrack{HistoryEntry: track.entry, Position: track.position, Duration: track.duration})
+	}
+
+	positions := time.NewTicker(statusPollInterval)
+	defer positions.Stop()
+
+	for {
+		var event PlayerEvent
+		select {
+		case event = <-events:
+		case <-positions.C:
+			for ip := range tracks {
+				updatePosition(ip)
+			}
+			continue
+		case <-server.stopping:
+			now := time.Now()
+			for ip := range tracks {
+				finish(ip, now)
+			}
+			return
+		}
+
+		now := event.Time
+		if event.Type == "offline" {
+			finish(event.IP, now)
+			continue
+		}
+		if event.Type == "players" {
+			// Players that left the network have stopped playing for us
+			players := server.knownPlayers()
+			for ip := range tracks {
+				if _, ok := findPlayer(players, ip); !ok {
+					finish(ip, now)
+				}
+			}
+			continue
+		}
+		if event.Status == nil || transitionalState(event.Status) {
+			continue
+		}
+
+		status := event.Status
+		stopped := playState(status) == "stopped"
+		track, ok := tracks[event.IP]
+		if ok && !sameTrack(track.entry, status) {
+			finish(event.IP, now)
+			ok = false
+		}
+		if !ok {
+			if status.Song == "" || stopped {
+				continue
+			}
+			track = &historyTrack{entry: HistoryEntry{
+				Player: event.Player,
+				IP:     event.IP,
+				Time:   now,
+				Song:   status.Song,
+				Artist: status.Artist,
+				Album:  status.Album,
+				Source: historySource(status),
+			}}
+			tracks[event.IP] = track
+		}
+		track.position = status.Position
+		if status.Duration > 0 {
+			track.duration = status.Duration
+		}
+
+		switch playState(status) {
+		case "playing":
+			if track.playingSince.IsZero() {
+				track.playingSince = now
+			}
+		case "paused":
+			track.pause(now)
+		default:
+			// Stopped: the track is over, playing it again is a new entry
+			finish(event.IP, now)
+		}
+	}
+}
+
+func sameTrack(entry HistoryEntry, status *Status) bool {
+	return entry.Song == status.Song && entry.Artist == status.Artist && entry.Album == status.Album
+}
+
+// Service reported by BluOS, or a guess from the Sonos track URI
+func historySource(status *Status) string {
+	if status.Service != "" {
+		return status.Service
+	}
+	uri := strings.ToLower(status.StreamURL)
+	for _, source := range []struct{ prefix, name string }{
+		{"x-sonos-spotify:", "Spotify"},
+		{"x-sonosapi-stream:", "Radio"},
+		{"x-sonosapi-radio:", "Radio"},
+		{"x-rincon-mp3radio:", "Radio"},
+		{"x-file-cifs:", "Library"},
+		{"x-rincon-stream:", "Line-In"},
+		{"x-sonos-htastream:", "TV"},
+		{"http:", "Stream"},
+		{"https:", "Stream"},
+	} {
+		if strings.HasPrefix(uri, source.prefix) {
+			return source.name
+		}
+	}
+	return ""
+}
+
+type ArtistStats struct {
+	Artist   string `json:"artist"`
+	Plays    int    `json:"plays"`
+	Listened int    `json:"listened"`
+}
+
+type RoomStats struct {
+	Player   string `json:"player"`
+	Plays    int    `json:"plays"`
+	Listened int    `json:"listened"`
+}
+
+type HistoryStats struct {
+	Since      time.Time     `json:"since"`
+	Plays      int           `json:"plays"`
+	Listened   int           `json:"listened"`
+	TopArtists []ArtistStats `json:"top_artists"`
+	Rooms      []RoomStats   `json:"rooms"`
+}
+
+// Totals of the entries since the given time. Artists are ranked by plays,
+// rooms by listening time.
+func historyStats(entries []HistoryEntry, since time.Time) HistoryStats {
+	stats := HistoryStats{Since: since}
+	artists := make(map[string]*ArtistStats)
+	rooms := make(map[string]*RoomStats)
+
+	for _, entry := range entries {
+		if entry.Time.Before(since) {
+			continue
+		}
+		stats.Plays++
+		stats.Listened += entry.Listened
+
+		if entry.Artist != "" {
+			key := strings.ToLower(entry.Artist)
+			if artists[key] == nil {
+				artists[key] = &ArtistStats{Artist: entry.Artist}
+			}
+			artists[key].Plays++
+			artists[key].Listened += entry.Listened
+		}
+		if rooms[entry.Player] == nil {
+			rooms[entry.Player] = &RoomStats{Player: entry.Player}
+		}
+		rooms[entry.Player].Plays++
+		rooms[entry.Player].Listened += entry.Listened
+	}
+
+	for _, artist := range artists {
+		stats.TopArtists = append(stats.TopArtists, *artist)
+	}
+	sort.Slice(stats.TopArtists, func(i, j int) bool {
+		a, b := stats.TopArtists[i], stats.TopArtists[j]
+		if a.Plays != b.Plays {
+			return a.Plays > b.Plays
+		}
+		return a.Listened > b.Listened
+	})
+	if len(stats.TopArtists) > statsTopArtists {
+		stats.TopArtists = stats.TopArtists[:statsTopArtists]
+	}
+
+	for _, room := range rooms {
+		stats.Rooms = append(stats.Rooms, *room)
+	}
+	sort.Slice(stats.Rooms, func(i, j int) bool {
+		return stats.Rooms[i].Listened > stats.Rooms[j].Listened
+	})
+	return stats
+}
+
+// Start of the day the given number of days back, counting today
+func statsSince(days int) time.Time {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, 1-days)
+}
+
+// Last n entries, newest first
+func recentHistory(entries []HistoryEntry, n int) []HistoryEntry {
+	var recent []HistoryEntry
+	for i := len(entries) - 1; i >= 0 && len(recent) < n; i-- {
+		recent = append(recent, entries[i])
+	}
+	return recent
+}
+
+// "4m10s" for tracks, "3h12m" for longer totals
+func formatListened(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	if d >= time.Hour {
+		return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	}
+	return d.String()
+}
+
+func formatHistory(entries []HistoryEntry) string {
+	if len(entries) == 0 {
+		return getText("no_history")
+	}
+
+	today := statsSince(1)
+	lines := []string{getText("history_title")}
+	for _, entry := range entries {
+		when := entry.Time.Local().Format("2006-01-02 15:04")
+		if !entry.Time.Before(today) {
+			when = entry.Time.Local().Format("15:04")
+		}
+		track := entry.Song
+		if entry.Artist != "" {
+			track += " - " + entry.Artist
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s: %s (%s)", when, entry.Player, track, formatListened(entry.Listened)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatStats(stats HistoryStats, days int) string {
+	if stats.Plays == 0 {
+		return getText("no_history")
+	}
+
+	lines := []string{fmt.Sprintf(getText("stats_title"), days, stats.Plays, formatListened(stats.Listened))}
+	if len(stats.TopArtists) > 0 {
+		lines = append(lines, getText("stats_top_artists"))
+		for i, artist := range stats.TopArtists {
+			lines = append(lines, fmt.Sprintf("  %d. %s (%d, %s)", i+1, artist.Artist, artist.Plays, formatListened(artist.Listened)))
+		}
+	}
+	lines = append(lines, getText("stats_rooms"))
+	for _, room := range stats.Rooms {
+		lines = append(lines, fmt.Sprintf("  %s: %s (%d)", room.Player, formatListened(room.Listened), room.Plays))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Count argument of "history [n]" and "stats [days]"
+func parseHistoryCount(args []string, fallback int) (int, bool) {
+	if len(args) == 0 {
+		return fallback, true
+	}
+	n, err := strconv.Atoi(args[0])
+	return n, err == nil && n > 0
+}
+
+// Handle "history [n]"
+func handleHistoryCommand(args []string) {
+	n, ok := parseHistoryCount(args, defaultHistoryCount)
+	if !ok {
+		reportUsage(getText("history_usage"))
+		return
+	}
+	entries, err := loadHistory()
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_history"), err))
+		return
+	}
+	tuiState.lastAction = formatHistory(recentHistory(entries, n))
+}
+
+// Handle "stats [days]"
+func handleStatsCommand(args []string) {
+	days, ok := parseHistoryCount(args, defaultStatsDays)
+	if !ok {
+		reportUsage(getText("stats_usage"))
+		return
+	}
+	entries, err := loadHistory()
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_history"), err))
+		return
+	}
+	tuiState.lastAction = formatStats(historyStats(entries, statsSince(days)), days)
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hooks of the serve and daemon modes, configured in hooks.json:
+//
+//	{
+//	  "hooks": [
+//	    {"events": ["play"], "player": "Living Room", "command": "~/bin/dim-lights.sh"},
+//	    {"events": ["track"], "url": "http://localhost:8123/api/webhook/music"}
+//	  ]
+//	}
+//
+// Events are "track", "play", "pause", "stop", "volume", "group", "offline"
+// and "online"; a hook without events gets all of them. Commands run in the
+// shell with the event in BLUESOUNDPLAYER_* variables and as JSON on stdin;
+// URLs get the JSON in a POST request.
+type HooksConfig struct {
+	Hooks []Hook `json:"hooks"`
+}
+
+type Hook struct {
+	Name    string   `json:"name,omitempty"`
+	Events  []string `json:"events,omitempty"`
+	Player  string   `json:"player,omitempty"`
+	Command string   `json:"command,omitempty"`
+	URL     string   `json:"url,omitempty"`
+}
+
+// Event passed to hooks
+type HookEvent struct {
+	Event  string    `json:"event"`
+	Player string    `json:"player"`
+	IP     string    `json:"ip"`
+	Time   time.Time `json:"time"`
+	State  string    `json:"state,omitempty"` // playing, paused or stopped
+	Song   string    `json:"song,omitempty"`
+	Artist string    `json:"artist,omitempty"`
+	Album  string    `json:"album,omitempty"`
+	Volume int       `json:"volume"`
+	Image  string    `json:"image,omitempty"`
+}
+
+const (
+	hooksFile   = "hooks.json"
+	hookTimeout = 30 * time.Second
+
+	// Hook runs waiting for earlier ones
+	hookQueueSize = 256
+
+	// Volume hooks wait until the volume held still this long, so a fade or
+	// a slider drag runs them once instead of at every step
+	hookVolumeDelay = 2 * time.Second
+)
+
+var hookEventNames = []string{"track", "play", "pause", "stop", "volume", "group", "offline", "online"}
+
+func loadHooks() (*HooksConfig, error) {
+	path, err := configPath(hooksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &HooksConfig{}
+	if err := loadJSONFile(path, config); err != nil {
+		return nil, err
+	}
+	for _, hook := range config.Hooks {
+		if (hook.Command == "") == (hook.URL == "") {
+			return nil, fmt.Errorf("hook %q needs either a command or a url", hook.label())
+		}
+		for _, event := range hook.Events {
+			if !slices.Contains(hookEventNames, event) {
+				return nil, fmt.Errorf("hook %q: unknown event %q", hook.label(), event)
+			}
+		}
+	}
+	return config, nil
+}
+
+func (c *HooksConfig) configured() bool {
+	return len(c.Hooks) > 0
+}
+
+func (h Hook) label() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	if h.Command != "" {
+		return h.Command
+	}
+	return h.URL
+}
+
+func (h Hook) matches(event HookEvent) bool {
+	if h.Player != "" && h.Player != event.IP && !strings.EqualFold(h.Player, event.Player) {
+		return false
+	}
+	return len(h.Events) == 0 || slices.Contains(h.Events, event.Event)
+}
+
+type hookRun struct {
+	hook  Hook
+	event HookEvent
+}
+
+// Volume change waiting for hookVolumeDelay; only the latest one per player
+// (highest gen) runs hooks
+type pendingVolume struct {
+	ip  string
+	gen int
+}
+
+// Turn the server's player events into hook events. Hooks run one after
+// another in the background, so they see the events in order and slow hooks
+// don't make the event bus drop events.
+func runHooks(server *apiServer, config *HooksConfig) {
+	events := server.events.Subscribe()
+	defer server.events.Unsubscribe(events)
+
+	runs := make(chan hookRun, hookQueueSize)
+	defer close(runs)
+	go func() {
+		for run := range runs {
+			runHook(run.hook, run.event)
+		}
+	}()
+
+	players := server.knownPlayers()
+	statuses := make(map[string]*Status)
+	offline := make(map[string]bool) // players that stopped answering
+	volumeGen := make(map[string]int)
+	volumeSettled := make(chan pendingVolume, hookQueueSize)
+
+	for {
+		var hookEvents []HookEvent
+		select {
+		case event := <-events:
+			switch event.Type {
+			case "players":
+				current := server.knownPlayers()
+				for _, player := range players {
+					if _, ok := findPlayer(current, player.IP); !ok {
+						if !offline[player.IP] {
+							hookEvents = append(hookEvents, newHookEvent("offline", player, statuses[player.IP]))
+						}
+						delete(statuses, player.IP)
+						delete(offline, player.IP)
+					}
+				}
+				for _, player := range current {
+					if _, ok := findPlayer(players, player.IP); !ok {
+						hookEvents = append(hookEvents, newHookEvent("online", player, nil))
+					}
+				}
+				players = current
+
+			case "offline":
+				if player, ok := findPlayer(players, event.IP); ok && !offline[event.IP] {
+					offline[event.IP] = true
+					hookEvents = append(hookEvents, newHookEvent("offline", player, statuses[event.IP]))
+				}
+
+			case "online":
+				if player, ok := findPlayer(players, event.IP); ok && offline[event.IP] {
+					delete(offline, event.IP)
+					statuses[event.IP] = event.Status
+					hookEvents = append(hookEvents, newHookEvent("online", player, event.Status))
+				}
+
+			case "topology":
+				if player, ok := findPlayer(players, event.IP); ok {
+					hookEvents = append(hookEvents, newHookEvent("group", player, statuses[event.IP]))
+				}
+
+			default:
+				player, ok := findPlayer(players, event.IP)
+				if !ok || event.Status == nil {
+					break
+				}
+				old := statuses[event.IP]
+				status := event.Status
+				if transitionalState(status) {
+					if old == nil {
+						break
+					}
+					// Keep the settled state until the player settles again
+					settled := *status
+					settled.State = old.State
+					status = &settled
+				}
+				statuses[event.IP] = status
+				// The first status only tells where the player is at
+				if old == nil {
+					break
+				}
+				for _, name := range hookChanges(old, status) {
+					if name == "volume" {
+						volumeGen[event.IP]++
+						pending := pendingVolume{event.IP, volumeGen[event.IP]}
+						time.AfterFunc(hookVolumeDelay, func() { volumeSettled <- pending })
+						continue
+					}
+					hookEvents = append(hookEvents, newHookEvent(name, player, status))
+				}
+			}
+
+		case pending := <-volumeSettled:
+			player, ok := findPlayer(players, pending.ip)
+			if ok && pending.gen == volumeGen[pending.ip] && statuses[pending.ip] != nil {
+				hookEvents = append(hookEvents, newHookEvent("volume", player, statuses[pending.ip]))
+			}
+		}
+
+		for _, hookEvent := range hookEvents {
+			for _, hook := range config.Hooks {
+				if !hook.matches(hookEvent) {
+					continue
+				}
+				select {
+				case runs <- hookRun{hook, hookEvent}:
+				default:
+					log.Printf("hook %q skipped for %s of %s: too many pending hooks", hook.label(), hookEvent.Event, hookEvent.Player)
+				}
+			}
+		}
+	}
+}
+
+// Hook events between two statuses; unlike statusChanges, new artwork or
+// stream URLs alone are no track change
+func hookChanges(old, current *Status) []string {
+	var changes []string
+	if state := playState(current); state != playState(old) {
+		changes = append(changes, map[string]string{"playing": "play", "paused": "pause", "stopped": "stop"}[state])
+	}
+	if current.Song != "" && (old.Song != current.Song || old.Artist != current.Artist || old.Album != current.Album) {
+		changes = append(changes, "track")
+	}
+	if old.Volume != current.Volume {
+		changes = append(changes, "volume")
+	}
+	return changes
+}
+
+func newHookEvent(name string, player PlayerInfo, status *Status) HookEvent {
+	event := HookEvent{Event: name, Player: player.Name, IP: player.IP, Time: time.Now()}
+	if status != nil {
+		event.State = playState(status)
+		event.Song = status.Song
+		event.Artist = status.Artist
+		event.Album = status.Album
+		event.Volume = status.Volume
+		event.Image = playerArtworkURL(player, status.Image)
+	}
+	return event
+}
+
+func runHook(hook Hook, event HookEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("hook %q: %v", hook.label(), err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	if hook.URL != "" {
+		err = postHook(ctx, hook.URL, data)
+	} else {
+		err = execHook(ctx, hook.Command, event, data)
+	}
+	if err != nil {
+		log.Printf("hook %q on %s of %s failed: %v", hook.label(), event.Event, event.Player, err)
+	}
+}
+
+func postHook(ctx context.Context, url string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func execHook(ctx context.Context, command string, event HookEvent, data []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"BLUESOUNDPLAYER_EVENT="+event.Event,
+		"BLUESOUNDPLAYER_PLAYER="+event.Player,
+		"BLUESOUNDPLAYER_IP="+event.IP,
+		"BLUESOUNDPLAYER_STATE="+event.State,
+		"BLUESOUNDPLAYER_SONG="+event.Song,
+		"BLUESOUNDPLAYER_ARTIST="+event.Artist,
+		"BLUESOUNDPLAYER_ALBUM="+event.Album,
+		"BLUESOUNDPLAYER_VOLUME="+strconv.Itoa(event.Volume),
+		"BLUESOUNDPLAYER_IMAGE="+event.Image,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil && len(output) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return err
+}
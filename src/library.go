@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// App-managed preset library. Entries are played through PlayURL, so an
+// entry ID means the same station on every player regardless of brand.
+type LibraryEntry struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	URL   string   `json:"url"`
+	Image string   `json:"image,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type Library struct {
+	// Active makes the library replace the player's own presets in the TUI
+	Active  bool           `json:"active"`
+	Entries []LibraryEntry `json:"entries"`
+	// ID of the next entry; kept so IDs of removed entries aren't handed out again
+	NextID int `json:"next_id,omitempty"`
+
+	path string
+}
+
+const libraryFile = "library.json"
+
+func loadLibrary() (*Library, error) {
+	path, err := configPath(libraryFile)
+	if err != nil {
+		return nil, err
+	}
+
+	library := &Library{path: path}
+	if err := loadJSONFile(path, library); err != nil {
+		return nil, err
+	}
+	return library, nil
+}
+
+func (l *Library) Save() error {
+	return saveJSONFile(l.path, l)
+}
+
+// IDs are never reused, so removing an entry doesn't shift the others
+func (l *Library) Add(entry LibraryEntry) LibraryEntry {
+	if l.NextID == 0 {
+		l.NextID = 1
+	}
+	entry.ID = l.NextID
+	l.NextID++
+	l.Entries = append(l.Entries, entry)
+	return entry
+}
+
+func (l *Library) Find(id int) (*LibraryEntry, bool) {
+	for i := range l.Entries {
+		if l.Entries[i].ID == id {
+			return &l.Entries[i], true
+		}
+	}
+	return nil, false
+}
+
+func (l *Library) FindURL(streamURL string) (*LibraryEntry, bool) {
+	for i := range l.Entries {
+		if l.Entries[i].URL == streamURL {
+			return &l.Entries[i], true
+		}
+	}
+	return nil, false
+}
+
+func (l *Library) Remove(id int) bool {
+	for i, entry := range l.Entries {
+		if entry.ID == id {
+			l.Entries = append(l.Entries[:i], l.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Library) Presets() []Preset {
+	var presets []Preset
+	for _, entry := range l.Entries {
+		presets = append(presets, Preset{ID: entry.ID, Name: entry.Name, URL: entry.URL, Image: entry.Image})
+	}
+	return presets
+}
+
+// Only plain stream URLs can be played on any brand. Sonos radio URIs are
+// turned back into the http stream they wrap.
+func portableStreamURL(uri string) (string, bool) {
+	if strings.HasPrefix(uri, "x-rincon-mp3radio://") {
+		return "http://" + strings.TrimPrefix(uri, "x-rincon-mp3radio://"), true
+	}
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return uri, true
+	}
+	return "", false
+}
+
+// Play preset <id> from the library when it is active, otherwise from the player
+func playPresetByID(id int) error {
+	if !libraryActive() {
+		return tuiState.client.PlayPreset(id)
+	}
+
+	entry, ok := tuiState.library.Find(id)
+	if !ok {
+		return fmt.Errorf("library entry %d not found", id)
+	}
+	return tuiState.client.PlayURL(entry.URL, entry.Name)
+}
+
+// Handle "lib ..." commands
+func handleLibraryCommand(args []string) {
+	if tuiState.library == nil {
+		library, err := loadLibrary()
+		if err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_library"), err))
+			return
+		}
+		tuiState.library = library
+	}
+	library := tuiState.library
+
+	if len(args) == 0 {
+		tuiState.lastAction = fmt.Sprintf(getText("library_info"), len(library.Entries), onOff(library.Active))
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on", "off":
+		library.Active = strings.ToLower(args[0]) == "on"
+		tuiState.lastAction = fmt.Sprintf(getText("library_mode"), onOff(library.Active))
+
+	case "add":
+		if len(args) < 3 {
+			reportUsage(getText("library_usage"))
+			return
+		}
+		entry := library.Add(LibraryEntry{Name: strings.Join(args[2:], " "), URL: args[1]})
+		tuiState.lastAction = fmt.Sprintf(getText("library_added"), entry.ID, entry.Name)
+
+	case "rm", "remove":
+		id, ok := libraryEntryID(args)
+		if !ok {
+			return
+		}
+		if !library.Remove(id) {
+			reportUsage(getText("invalid_preset_id"))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("library_removed"), id)
+
+	case "tag":
+		id, ok := libraryEntryID(args)
+		if !ok {
+			return
+		}
+		entry, found := library.Find(id)
+		if !found {
+			reportUsage(getText("invalid_preset_id"))
+			return
+		}
+		entry.Tags = args[2:]
+		tuiState.lastAction = fmt.Sprintf(getText("library_tagged"), entry.Name, strings.Join(entry.Tags, ", "))
+
+	case "play":
+		id, ok := libraryEntryID(args)
+		if !ok {
+			return
+		}
+		entry, found := library.Find(id)
+		if !found {
+			reportUsage(getText("invalid_preset_id"))
+			return
+		}
+		if err := tuiState.client.PlayURL(entry.URL, entry.Name); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_playing_url"), err))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("playing_url"), entry.Name)
+		updateStatus()
+		return
+
+	case "import":
+		imported, skipped, importErr := importDevicePresets(library)
+		if importErr != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_library"), importErr))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("library_imported"), imported, skipped)
+
+	case "push":
+		id, ok := libraryEntryID(args)
+		if !ok {
+			return
+		}
+		entry, found := library.Find(id)
+		if !found {
+			reportUsage(getText("invalid_preset_id"))
+			return
+		}
+		manager, ok := currentPresetManager()
+		if !ok {
+			return
+		}
+		slot := 0
+		if len(args) > 2 {
+			if slot, ok = parsePresetID(args[2]); !ok {
+				return
+			}
+		}
+		preset := Preset{ID: slot, Name: entry.Name, URL: entry.URL, Image: entry.Image}
+		if err := manager.SavePreset(preset); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_editing_preset"), err))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("library_pushed"), entry.Name)
+
+	default:
+		reportUsage(getText("library_usage"))
+		return
+	}
+
+	if err := library.Save(); err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_library"), err))
+	}
+	updatePresets()
+}
+
+func libraryEntryID(args []string) (int, bool) {
+	if len(args) < 2 {
+		reportUsage(getText("library_usage"))
+		return 0, false
+	}
+	return parsePresetID(args[1])
+}
+
+// Copy the current player's presets/favorites with portable stream URLs
+func importDevicePresets(library *Library) (int, int, error) {
+	presets, err := tuiState.client.GetPresets()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	imported, skipped := 0, 0
+	for _, preset := range presets {
+		streamURL, ok := portableStreamURL(preset.URL)
+		if !ok {
+			skipped++
+			continue
+		}
+		if _, exists := library.FindURL(streamURL); exists {
+			skipped++
+			continue
+		}
+		library.Add(LibraryEntry{Name: preset.Name, URL: streamURL, Image: preset.Image})
+		imported++
+	}
+
+	return imported, skipped, nil
+}
+
+func onOff(active bool) string {
+	if active {
+		return "on"
+	}
+	return "off"
+}
+
+func libraryTags(id int) string {
+	if !libraryActive() {
+		return ""
+	}
+	if entry, ok := tuiState.library.Find(id); ok && len(entry.Tags) > 0 {
+		return " #" + strings.Join(entry.Tags, " #")
+	}
+	return ""
+}
+
+func libraryActive() bool {
+	return tuiState.library != nil && tuiState.library.Active
+}
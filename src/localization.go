@@ -14,229 +14,589 @@ var currentLanguage = LangEnglish
 // Localization texts
 var texts = map[Language]map[string]string{
 	LangEnglish: {
-		"title":                   "🎵 Multi-Room Audio Controller",
-		"scanning":                "🔍 Scanning network for audio players...",
-		"scanning_network":        "   Scanning network: %s",
-		"scanning_interface":      "   Interface %s: %s",
-		"found_player":            "   ✅ Found: %s (%s) at %s",
-		"no_players":              "no audio players found",
-		"could_not_determine_ip":  "could not determine local IP: %w",
-		"available_players":       "📱 Available Players:",
-		"select_player":           "Select a player (1-%d): ",
-		"invalid_selection":       "❌ Invalid selection",
-		"connected_to":            "✅ Connected to: %s (%s)",
-		"error_selecting_player":  "Error selecting player: %v",
-		"interactive_mode":        "🎵 Multi-Room Audio Controller - Interactive Mode",
-		"separator":               "=======================================",
-		"status_volume":           "📊 Status: %s | Volume: %s",
-		"volume_unknown":          "N/A",
-		"error_retrieving_status": "❌ Error retrieving status",
-		"available_presets":       "📋 Available Presets/Favorites:",
-		"error_loading_presets":   "❌ Error loading presets/favorites",
-		"available_commands":      "🎮 Available Commands:",
-		"cmd_play_preset":         "play <id>   - Play preset/favorite",
-		"cmd_play":                "play       - Start playback",
-		"cmd_pause":               "pause      - Pause playback",
-		"cmd_stop":                "stop       - Stop playback",
-		"cmd_next":                "next       - Next track",
-		"cmd_prev":                "prev       - Previous track",
-		"cmd_volume":              "vol <0-100> - Set volume",
-		"cmd_status":              "status     - Refresh status",
-		"cmd_presets":             "presets    - Refresh presets/favorites",
-		"cmd_help":                "help       - Show help",
-		"cmd_lang":                "lang <en|de|sw> - Change language",
-		"cmd_output":              "output <id> - Switch to player",
-		"cmd_group":               "group <id1+id2> - Group players",
-		"cmd_ungroup":             "ungroup - Remove all groups",
-		"cmd_debug":               "debug - Show API endpoints",
-		"cmd_quit":                "quit/exit  - Exit program",
-		"prompt":                  "Command> ",
-		"invalid_preset_id":       "❌ Invalid preset/favorite ID",
-		"error_playing_preset":    "❌ Error playing preset/favorite",
-		"playing_preset":          "✅ Playing preset/favorite %d",
-		"error_starting_playback": "❌ Error starting playback",
-		"playback_started":        "▶️ Playback started",
-		"error_pausing":           "❌ Error pausing",
-		"paused":                  "⏸️ Paused",
-		"error_stopping":          "❌ Error stopping",
-		"stopped":                 "⏹️ Stopped",
-		"error_next_track":        "❌ Error skipping to next track",
-		"next_track":              "⏭️ Next track",
-		"error_prev_track":        "❌ Error going to previous track",
-		"prev_track":              "⏮️ Previous track",
-		"volume_missing":          "❌ Volume value missing",
-		"invalid_volume":          "❌ Invalid volume value",
-		"error_setting_volume":    "❌ Error setting volume",
-		"volume_set":              "🔊 Volume set to %d%%",
-		"language_changed":        "🌍 Language changed to",
-		"invalid_language":        "❌ Invalid language. Use: en, de, sw",
-		"goodbye":                 "👋 Goodbye!",
-		"unknown_command":         "❌ Unknown command: %s (Type 'help' for help)",
-		"last_action":             "Last Action:",
-		"no_song_playing":         "No song playing",
-		"available_outputs":       "📱 Available Players:",
-		"current_player":          "Current Player:",
-		"switched_to_player":      "🔄 Switched to player %d: %s",
-		"invalid_player_id":       "❌ Invalid player ID",
-		"error_switching_player":  "❌ Error switching to player",
-		"grouped_players":         "🔗 Grouped players: %s as master",
-		"invalid_group_format":    "❌ Invalid group format. Use: group <id1+id2>",
-		"error_grouping":          "❌ Error grouping players",
-		"group_combinations":      "🎵 Group Combinations:",
-		"ungrouped_all":           "🔓 All player groups removed",
-		"error_ungrouping":        "❌ Error removing groups",
-		"scanning_interfaces":     "🔍 Found %d network interfaces to scan",
-		"completed_scan":          "✅ Completed scanning %d networks",
+		"title":                     "🎵 Multi-Room Audio Controller",
+		"scanning":                  "🔍 Scanning network for audio players...",
+		"scanning_network":          "   Scanning network: %s",
+		"scanning_interface":        "   Interface %s: %s",
+		"found_player":              "   ✅ Found: %s (%s) at %s",
+		"no_players":                "no audio players found",
+		"could_not_determine_ip":    "could not determine local IP: %w",
+		"available_players":         "📱 Available Players:",
+		"select_player":             "Select a player (1-%d): ",
+		"invalid_selection":         "❌ Invalid selection",
+		"connected_to":              "✅ Connected to: %s (%s)",
+		"error_selecting_player":    "Error selecting player: %v",
+		"interactive_mode":          "🎵 Multi-Room Audio Controller - Interactive Mode",
+		"separator":                 "=======================================",
+		"status_volume":             "📊 Status: %s | Volume: %s",
+		"volume_unknown":            "N/A",
+		"error_retrieving_status":   "❌ Error retrieving status",
+		"available_presets":         "📋 Available Presets/Favorites:",
+		"error_loading_presets":     "❌ Error loading presets/favorites",
+		"available_commands":        "🎮 Available Commands:",
+		"cmd_play_preset":           "play <id>   - Play preset/favorite",
+		"cmd_play":                  "play       - Start playback",
+		"cmd_pause":                 "pause      - Pause playback",
+		"cmd_stop":                  "stop       - Stop playback",
+		"cmd_next":                  "next       - Next track",
+		"cmd_prev":                  "prev       - Previous track",
+		"cmd_volume":                "vol <0-100> - Set volume",
+		"cmd_status":                "status     - Refresh status",
+		"cmd_presets":               "presets    - Refresh presets/favorites",
+		"cmd_help":                  "help       - Show help",
+		"cmd_lang":                  "lang <en|de|sw> - Change language",
+		"cmd_output":                "output <id> - Switch to player",
+		"cmd_group":                 "group <id1+id2> - Group players",
+		"cmd_ungroup":               "ungroup - Remove all groups",
+		"cmd_debug":                 "debug - Show API endpoints",
+		"cmd_quit":                  "quit/exit  - Exit program",
+		"prompt":                    "Command> ",
+		"invalid_preset_id":         "❌ Invalid preset/favorite ID",
+		"error_playing_preset":      "❌ Error playing preset/favorite",
+		"playing_preset":            "✅ Playing preset/favorite %d",
+		"error_starting_playback":   "❌ Error starting playback",
+		"playback_started":          "▶️ Playback started",
+		"error_pausing":             "❌ Error pausing",
+		"paused":                    "⏸️ Paused",
+		"error_stopping":            "❌ Error stopping",
+		"stopped":                   "⏹️ Stopped",
+		"error_next_track":          "❌ Error skipping to next track",
+		"next_track":                "⏭️ Next track",
+		"error_prev_track":          "❌ Error going to previous track",
+		"prev_track":                "⏮️ Previous track",
+		"volume_missing":            "❌ Volume value missing",
+		"invalid_volume":            "❌ Invalid volume value",
+		"error_setting_volume":      "❌ Error setting volume",
+		"volume_set":                "🔊 Volume set to %d%%",
+		"language_changed":          "🌍 Language changed to",
+		"invalid_language":          "❌ Invalid language. Use: en, de, sw",
+		"goodbye":                   "👋 Goodbye!",
+		"unknown_command":           "❌ Unknown command: %s (Type 'help' for help)",
+		"last_action":               "Last Action:",
+		"no_song_playing":           "No song playing",
+		"available_outputs":         "📱 Available Players:",
+		"current_player":            "Current Player:",
+		"switched_to_player":        "🔄 Switched to player %d: %s",
+		"invalid_player_id":         "❌ Invalid player ID",
+		"error_switching_player":    "❌ Error switching to player",
+		"grouped_players":           "🔗 Grouped players: %s as master",
+		"invalid_group_format":      "❌ Invalid group format. Use: group <id1+id2>",
+		"error_grouping":            "❌ Error grouping players",
+		"group_combinations":        "🎵 Group Combinations:",
+		"ungrouped_all":             "🔓 All player groups removed",
+		"error_ungrouping":          "❌ Error removing groups",
+		"scanning_interfaces":       "🔍 Found %d network interfaces to scan",
+		"completed_scan":            "✅ Completed scanning %d networks",
+		"cmd_playurl":               "playurl <url> [title] - Play stream URL",
+		"url_missing":               "❌ Stream URL missing",
+		"playing_url":               "📻 Playing stream: %s",
+		"error_playing_url":         "❌ Error playing stream",
+		"queue_title":               "📜 Queue:",
+		"queue_empty":               "Queue is empty",
+		"queue_more":                "  ... %d more",
+		"error_loading_queue":       "❌ Error loading queue",
+		"queue_refreshed":           "📜 Queue refreshed",
+		"queue_usage":               "❌ Use: queue [add|next <url>] [rm <n>] [mv <from> <to>] [play <n>] [clear] [save <name>]",
+		"queue_added":               "➕ Added to queue",
+		"queue_removed":             "➖ Removed track %d from queue",
+		"queue_moved":               "↕️ Moved track %d to position %d",
+		"queue_cleared":             "🗑️ Queue cleared",
+		"queue_playing":             "▶️ Playing queue track %d",
+		"queue_saved":               "💾 Queue saved as playlist: %s",
+		"error_queue":               "❌ Queue operation failed",
+		"invalid_queue_index":       "❌ Invalid queue position",
+		"browse_title":              "📂 Browse:",
+		"browse_root":               "Home",
+		"browse_empty":              "(empty)",
+		"browse_more_available":     "... more available (type 'more')",
+		"browse_not_supported":      "❌ Browsing not supported by this player",
+		"browse_not_open":           "❌ Browser not open (type 'browse')",
+		"invalid_browse_item":       "❌ Invalid item number",
+		"error_browsing":            "❌ Error browsing",
+		"browse_opened":             "📂 Browser opened",
+		"browse_entered":            "📂 Opened: %s",
+		"browse_not_container":      "❌ This item cannot be opened",
+		"browse_up":                 "⬆️ Back to previous level",
+		"browse_closed":             "📂 Browser closed",
+		"browse_no_more":            "❌ No further items",
+		"browse_next_page":          "📄 Next page",
+		"browse_queued":             "➕ Added to queue: %s",
+		"browse_playing":            "▶️ Playing: %s",
+		"search_missing":            "❌ Search text missing",
+		"error_searching":           "❌ Error searching",
+		"search_results":            "🔍 %d results for '%s' (bplay <n> / bqueue <n>)",
+		"search_source_presets":     "Preset",
+		"no_preset_match":           "❌ No preset/favorite matches '%s'",
+		"ambiguous_preset":          "❓ Several presets/favorites match '%s':",
+		"select_preset":             "Select a preset (1-%d): ",
+		"selection_cancelled":       "❌ Selection cancelled",
+		"preset_usage":              "❌ Use: preset save [slot] [name] | rename <id> <name> | delete <id> | move <from> <to>",
+		"preset_edit_not_supported": "❌ Presets can't be edited on this player",
+		"preset_saved":              "💾 Saved preset: %s",
+		"preset_renamed":            "✏️ Preset %d renamed to %s",
+		"preset_deleted":            "🗑️ Preset %d deleted",
+		"preset_moved":              "↕️ Preset %d moved to slot %d",
+		"error_editing_preset":      "❌ Error editing presets",
+		"library_title":             "📚 Preset Library:",
+		"error_library":             "❌ Preset library error",
+		"library_info":              "📚 Preset library: %d entries, library mode %s",
+		"library_mode":              "📚 Library mode %s",
+		"library_usage":             "❌ Use: lib [on|off] | add <url> <name> | rm <id> | tag <id> <tags> | play <id> | push <id> [slot] | import",
+		"library_added":             "📚 Added library entry %d: %s",
+		"library_removed":           "🗑️ Library entry %d removed",
+		"library_tagged":            "🏷️ Tags for %s: %s",
+		"library_imported":          "📥 Imported %d presets into the library (%d skipped)",
+		"library_pushed":            "📤 Pushed %s to the player",
+		"presets_transfer_usage":    "❌ Use: presets export <file> | presets import <file>",
+		"error_exporting_presets":   "❌ Error exporting presets",
+		"error_importing_presets":   "❌ Error importing presets",
+		"presets_exported":          "💾 Exported %d presets to %s",
+		"presets_imported":          "📥 Import: %d added, %d unchanged, %d conflicts, %d failed",
+		"presets_conflicts":         "⚠️ Conflicts (existing presets kept):",
+		"presets_failed":            "❌ Failed:",
+		"sleep_usage":               "❌ Use: sleep <minutes|30m|1h30m> | sleep off | sleep status",
+		"sleep_set":                 "💤 Sleep timer set to %s",
+		"sleep_set_app":             "💤 Sleep timer set to %s (fades out and stops while the app is running)",
+		"sleep_off":                 "💤 Sleep timer cancelled",
+		"sleep_none":                "💤 No sleep timer set",
+		"sleep_remaining":           "💤 Sleep timer: %s left",
+		"error_sleep_timer":         "❌ Error setting sleep timer",
+		"fade_usage":                "❌ Use: fade <0-100> <duration> [linear|curved] | fade stop | stop --fade <duration>",
+		"fade_cancelled":            "🔉 Fade cancelled",
+		"fading_volume":             "🔉 Fading volume to %d%% over %s",
+		"fading_out":                "🔉 Fading out over %s, then stopping",
+		"next_alarm":                "⏰ Next alarm: %s - %s",
+		"alarms_title":              "⏰ Alarms:",
+		"no_alarms":                 "⏰ No alarms set",
+		"alarms_not_supported":      "❌ This player does not support alarms",
+		"error_alarm":               "❌ Alarm error",
+		"alarm_usage":               "❌ Use: alarm [list] | alarm add <HH:MM> <days> <preset> [volume] | alarm remove|enable|disable <id>",
+		"alarm_added":               "⏰ Alarm set for %s (%s)",
+		"alarm_removed":             "⏰ Alarm %d removed",
+		"alarm_toggled":             "⏰ Alarm %d switched %s",
+		"error_scene":               "❌ Scene error",
+		"no_scenes":                 "🎬 No scenes defined (see scenes.json)",
+		"scenes_title":              "🎬 Scenes:",
+		"scene_not_found":           "❌ Scene '%s' not found",
+		"scene_applied":             "🎬 Scene '%s' applied",
+		"web_title":                 "Multi-Room Audio",
+		"web_players":               "Players",
+		"web_group_hint":            "Drag a player onto another to group them. Click a player to see its presets.",
+		"web_presets":               "Presets",
+		"web_no_presets":            "No presets found",
+		"web_loading":               "Loading...",
+		"web_nothing_playing":       "Nothing playing",
+		"web_offline":               "Offline",
+		"web_state_playing":         "Playing",
+		"web_state_paused":          "Paused",
+		"web_state_stopped":         "Stopped",
+		"web_play":                  "Play",
+		"web_pause":                 "Pause",
+		"web_stop":                  "Stop",
+		"web_next":                  "Next track",
+		"web_prev":                  "Previous track",
+		"web_ungroup":               "Ungroup",
+		"web_volume":                "Volume",
+		"web_connected":             "🟢 Live",
+		"web_disconnected":          "🔴 Connection lost, reconnecting...",
+		"history_title":             "📜 Recently played:",
+		"no_history":                "📜 No play history yet (recorded while serve or daemon mode runs)",
+		"error_history":             "❌ History error",
+		"history_usage":             "❌ Usage: history [number of tracks]",
+		"stats_usage":               "❌ Usage: stats [days]",
+		"stats_title":               "📊 Last %d days: %d tracks, %s",
+		"stats_top_artists":         "🎤 Top artists:",
+		"stats_rooms":               "🏠 Rooms:",
 	},
 	LangGerman: {
-		"title":                   "🎵 Multi-Room Audio Controller",
-		"scanning":                "🔍 Suche nach Audio-Playern im Netzwerk...",
-		"scanning_network":        "   Scanne Netzwerk: %s",
-		"scanning_interface":      "   Interface %s: %s",
-		"found_player":            "   ✅ Gefunden: %s (%s) auf %s",
-		"no_players":              "keine Audio-Player gefunden",
-		"could_not_determine_ip":  "konnte lokale IP nicht ermitteln: %w",
-		"available_players":       "📱 Verfügbare Player:",
-		"select_player":           "Wähle einen Player (1-%d): ",
-		"invalid_selection":       "❌ Ungültige Auswahl",
-		"connected_to":            "✅ Verbunden mit: %s (%s)",
-		"error_selecting_player":  "Fehler bei der Player-Auswahl: %v",
-		"interactive_mode":        "🎵 Multi-Room Audio Controller - Interaktiver Modus",
-		"separator":               "==========================================",
-		"status_volume":           "📊 Status: %s | Lautstärke: %s",
-		"volume_unknown":          "N/A",
-		"error_retrieving_status": "❌ Fehler beim Abrufen des Status",
-		"available_presets":       "📋 Verfügbare Presets/Favoriten:",
-		"error_loading_presets":   "❌ Fehler beim Laden der Presets/Favoriten",
-		"available_commands":      "🎮 Verfügbare Befehle:",
-		"cmd_play_preset":         "play <id>   - Preset/Favorit abspielen",
-		"cmd_play":                "play       - Wiedergabe starten",
-		"cmd_pause":               "pause      - Pausieren",
-		"cmd_stop":                "stop       - Stoppen",
-		"cmd_next":                "next       - Nächster Titel",
-		"cmd_prev":                "prev       - Vorheriger Titel",
-		"cmd_volume":              "vol <0-100> - Lautstärke setzen",
-		"cmd_status":              "status     - Status aktualisieren",
-		"cmd_presets":             "presets    - Presets/Favoriten aktualisieren",
-		"cmd_help":                "help       - Hilfe anzeigen",
-		"cmd_lang":                "lang <en|de|sw> - Sprache ändern",
-		"cmd_output":              "output <id> - Zu Player wechseln",
-		"cmd_group":               "group <id1+id2> - Player gruppieren",
-		"cmd_ungroup":             "ungroup - Alle Gruppen auflösen",
-		"cmd_debug":               "debug - API-Endpunkte anzeigen",
-		"cmd_quit":                "quit/exit  - Programm beenden",
-		"prompt":                  "Befehl> ",
-		"invalid_preset_id":       "❌ Ungültige Preset/Favoriten-ID",
-		"error_playing_preset":    "❌ Fehler beim Abspielen",
-		"playing_preset":          "✅ Preset/Favorit %d wird abgespielt",
-		"error_starting_playback": "❌ Fehler beim Starten",
-		"playback_started":        "▶️ Wiedergabe gestartet",
-		"error_pausing":           "❌ Fehler beim Pausieren",
-		"paused":                  "⏸️ Pausiert",
-		"error_stopping":          "❌ Fehler beim Stoppen",
-		"stopped":                 "⏹️ Gestoppt",
-		"error_next_track":        "❌ Fehler beim Weiterschalten",
-		"next_track":              "⏭️ Nächster Titel",
-		"error_prev_track":        "❌ Fehler beim Zurückschalten",
-		"prev_track":              "⏮️ Vorheriger Titel",
-		"volume_missing":          "❌ Lautstärke-Wert fehlt",
-		"invalid_volume":          "❌ Ungültiger Lautstärke-Wert",
-		"error_setting_volume":    "❌ Fehler beim Setzen der Lautstärke",
-		"volume_set":              "🔊 Lautstärke auf %d%% gesetzt",
-		"language_changed":        "🌍 Sprache geändert zu",
-		"invalid_language":        "❌ Ungültige Sprache. Verwende: en, de, sw",
-		"goodbye":                 "👋 Auf Wiedersehen!",
-		"unknown_command":         "❌ Unbekannter Befehl: %s (Tippe 'help' für Hilfe)",
-		"last_action":             "Letzte Aktion:",
-		"no_song_playing":         "Kein Lied wird abgespielt",
-		"available_outputs":       "📱 Verfügbare Player:",
-		"current_player":          "Aktueller Player:",
-		"switched_to_player":      "🔄 Gewechselt zu Player %d: %s",
-		"invalid_player_id":       "❌ Ungültige Player-ID",
-		"error_switching_player":  "❌ Fehler beim Wechseln des Players",
-		"grouped_players":         "🔗 Player gruppiert: %s als Master",
-		"invalid_group_format":    "❌ Ungültiges Gruppen-Format. Verwende: group <id1+id2>",
-		"error_grouping":          "❌ Fehler beim Gruppieren",
-		"group_combinations":      "🎵 Gruppen-Kombinationen:",
-		"ungrouped_all":           "🔓 Alle Player-Gruppen aufgelöst",
-		"error_ungrouping":        "❌ Fehler beim Auflösen der Gruppen",
-		"scanning_interfaces":     "🔍 %d Netzwerkschnittstellen gefunden zum Scannen",
-		"completed_scan":          "✅ Scannen von %d Netzwerken abgeschlossen",
+		"title":                     "🎵 Multi-Room Audio Controller",
+		"scanning":                  "🔍 Suche nach Audio-Playern im Netzwerk...",
+		"scanning_network":          "   Scanne Netzwerk: %s",
+		"scanning_interface":        "   Interface %s: %s",
+		"found_player":              "   ✅ Gefunden: %s (%s) auf %s",
+		"no_players":                "keine Audio-Player gefunden",
+		"could_not_determine_ip":    "konnte lokale IP nicht ermitteln: %w",
+		"available_players":         "📱 Verfügbare Player:",
+		"select_player":             "Wähle einen Player (1-%d): ",
+		"invalid_selection":         "❌ Ungültige Auswahl",
+		"connected_to":              "✅ Verbunden mit: %s (%s)",
+		"error_selecting_player":    "Fehler bei der Player-Auswahl: %v",
+		"interactive_mode":          "🎵 Multi-Room Audio Controller - Interaktiver Modus",
+		"separator":                 "==========================================",
+		"status_volume":             "📊 Status: %s | Lautstärke: %s",
+		"volume_unknown":            "N/A",
+		"error_retrieving_status":   "❌ Fehler beim Abrufen des Status",
+		"available_presets":         "📋 Verfügbare Presets/Favoriten:",
+		"error_loading_presets":     "❌ Fehler beim Laden der Presets/Favoriten",
+		"available_commands":        "🎮 Verfügbare Befehle:",
+		"cmd_play_preset":           "play <id>   - Preset/Favorit abspielen",
+		"cmd_play":                  "play       - Wiedergabe starten",
+		"cmd_pause":                 "pause      - Pausieren",
+		"cmd_stop":                  "stop       - Stoppen",
+		"cmd_next":                  "next       - Nächster Titel",
+		"cmd_prev":                  "prev       - Vorheriger Titel",
+		"cmd_volume":                "vol <0-100> - Lautstärke setzen",
+		"cmd_status":                "status     - Status aktualisieren",
+		"cmd_presets":               "presets    - Presets/Favoriten aktualisieren",
+		"cmd_help":                  "help       - Hilfe anzeigen",
+		"cmd_lang":                  "lang <en|de|sw> - Sprache ändern",
+		"cmd_output":                "output <id> - Zu Player wechseln",
+		"cmd_group":                 "group <id1+id2> - Player gruppieren",
+		"cmd_ungroup":               "ungroup - Alle Gruppen auflösen",
+		"cmd_debug":                 "debug - API-Endpunkte anzeigen",
+		"cmd_quit":                  "quit/exit  - Programm beenden",
+		"prompt":                    "Befehl> ",
+		"invalid_preset_id":         "❌ Ungültige Preset/Favoriten-ID",
+		"error_playing_preset":      "❌ Fehler beim Abspielen",
+		"playing_preset":            "✅ Preset/Favorit %d wird abgespielt",
+		"error_starting_playback":   "❌ Fehler beim Starten",
+		"playback_started":          "▶️ Wiedergabe gestartet",
+		"error_pausing":             "❌ Fehler beim Pausieren",
+		"paused":                    "⏸️ Pausiert",
+		"error_stopping":            "❌ Fehler beim Stoppen",
+		"stopped":                   "⏹️ Gestoppt",
+		"error_next_track":          "❌ Fehler beim Weiterschalten",
+		"next_track":                "⏭️ Nächster Titel",
+		"error_prev_track":          "❌ Fehler beim Zurückschalten",
+		"prev_track":                "⏮️ Vorheriger Titel",
+		"volume_missing":            "❌ Lautstärke-Wert fehlt",
+		"invalid_volume":            "❌ Ungültiger Lautstärke-Wert",
+		"error_setting_volume":      "❌ Fehler beim Setzen der Lautstärke",
+		"volume_set":                "🔊 Lautstärke auf %d%% gesetzt",
+		"language_changed":          "🌍 Sprache geändert zu",
+		"invalid_language":          "❌ Ungültige Sprache. Verwende: en, de, sw",
+		"goodbye":                   "👋 Auf Wiedersehen!",
+		"unknown_command":           "❌ Unbekannter Befehl: %s (Tippe 'help' für Hilfe)",
+		"last_action":               "Letzte Aktion:",
+		"no_song_playing":           "Kein Lied wird abgespielt",
+		"available_outputs":         "📱 Verfügbare Player:",
+		"current_player":            "Aktueller Player:",
+		"switched_to_player":        "🔄 Gewechselt zu Player %d: %s",
+		"invalid_player_id":         "❌ Ungültige Player-ID",
+		"error_switching_player":    "❌ Fehler beim Wechseln des Players",
+		"grouped_players":           "🔗 Player gruppiert: %s als Master",
+		"invalid_group_format":      "❌ Ungültiges Gruppen-Format. Verwende: group <id1+id2>",
+		"error_grouping":            "❌ Fehler beim Gruppieren",
+		"group_combinations":        "🎵 Gruppen-Kombinationen:",
+		"ungrouped_all":             "🔓 Alle Player-Gruppen aufgelöst",
+		"error_ungrouping":          "❌ Fehler beim Auflösen der Gruppen",
+		"scanning_interfaces":       "🔍 %d Netzwerkschnittstellen gefunden zum Scannen",
+		"completed_scan":            "✅ Scannen von %d Netzwerken abgeschlossen",
+		"cmd_playurl":               "playurl <url> [titel] - Stream-URL abspielen",
+		"url_missing":               "❌ Stream-URL fehlt",
+		"playing_url":               "📻 Stream wird abgespielt: %s",
+		"error_playing_url":         "❌ Fehler beim Abspielen des Streams",
+		"queue_title":               "📜 Warteschlange:",
+		"queue_empty":               "Warteschlange ist leer",
+		"queue_more":                "  ... %d weitere",
+		"error_loading_queue":       "❌ Fehler beim Laden der Warteschlange",
+		"queue_refreshed":           "📜 Warteschlange aktualisiert",
+		"queue_usage":               "❌ Verwende: queue [add|next <url>] [rm <n>] [mv <von> <nach>] [play <n>] [clear] [save <name>]",
+		"queue_added":               "➕ Zur Warteschlange hinzugefügt",
+		"queue_removed":             "➖ Titel %d aus der Warteschlange entfernt",
+		"queue_moved":               "↕️ Titel %d auf Position %d verschoben",
+		"queue_cleared":             "🗑️ Warteschlange geleert",
+		"queue_playing":             "▶️ Spiele Titel %d der Warteschlange",
+		"queue_saved":               "💾 Warteschlange als Playlist gespeichert: %s",
+		"error_queue":               "❌ Warteschlangen-Aktion fehlgeschlagen",
+		"invalid_queue_index":       "❌ Ungültige Position in der Warteschlange",
+		"browse_title":              "📂 Durchsuchen:",
+		"browse_root":               "Start",
+		"browse_empty":              "(leer)",
+		"browse_more_available":     "... weitere Einträge verfügbar ('more' eingeben)",
+		"browse_not_supported":      "❌ Durchsuchen wird von diesem Player nicht unterstützt",
+		"browse_not_open":           "❌ Browser nicht geöffnet ('browse' eingeben)",
+		"invalid_browse_item":       "❌ Ungültige Eintragsnummer",
+		"error_browsing":            "❌ Fehler beim Durchsuchen",
+		"browse_opened":             "📂 Browser geöffnet",
+		"browse_entered":            "📂 Geöffnet: %s",
+		"browse_not_container":      "❌ Dieser Eintrag kann nicht geöffnet werden",
+		"browse_up":                 "⬆️ Zurück zur vorherigen Ebene",
+		"browse_closed":             "📂 Browser geschlossen",
+		"browse_no_more":            "❌ Keine weiteren Einträge",
+		"browse_next_page":          "📄 Nächste Seite",
+		"browse_queued":             "➕ Zur Warteschlange hinzugefügt: %s",
+		"browse_playing":            "▶️ Spiele: %s",
+		"search_missing":            "❌ Suchtext fehlt",
+		"error_searching":           "❌ Fehler bei der Suche",
+		"search_results":            "🔍 %d Treffer für '%s' (bplay <n> / bqueue <n>)",
+		"search_source_presets":     "Preset",
+		"no_preset_match":           "❌ Kein Preset/Favorit passt zu '%s'",
+		"ambiguous_preset":          "❓ Mehrere Presets/Favoriten passen zu '%s':",
+		"select_preset":             "Wähle ein Preset (1-%d): ",
+		"selection_cancelled":       "❌ Auswahl abgebrochen",
+		"preset_usage":              "❌ Verwende: preset save [platz] [name] | rename <id> <name> | delete <id> | move <von> <nach>",
+		"preset_edit_not_supported": "❌ Presets können auf diesem Player nicht bearbeitet werden",
+		"preset_saved":              "💾 Preset gespeichert: %s",
+		"preset_renamed":            "✏️ Preset %d umbenannt in %s",
+		"preset_deleted":            "🗑️ Preset %d gelöscht",
+		"preset_moved":              "↕️ Preset %d auf Platz %d verschoben",
+		"error_editing_preset":      "❌ Fehler beim Bearbeiten der Presets",
+		"library_title":             "📚 Preset-Bibliothek:",
+		"error_library":             "❌ Fehler in der Preset-Bibliothek",
+		"library_info":              "📚 Preset-Bibliothek: %d Einträge, Bibliotheksmodus %s",
+		"library_mode":              "📚 Bibliotheksmodus %s",
+		"library_usage":             "❌ Verwende: lib [on|off] | add <url> <name> | rm <id> | tag <id> <tags> | play <id> | push <id> [platz] | import",
+		"library_added":             "📚 Bibliothekseintrag %d hinzugefügt: %s",
+		"library_removed":           "🗑️ Bibliothekseintrag %d entfernt",
+		"library_tagged":            "🏷️ Tags für %s: %s",
+		"library_imported":          "📥 %d Presets in die Bibliothek importiert (%d übersprungen)",
+		"library_pushed":            "📤 %s auf den Player übertragen",
+		"presets_transfer_usage":    "❌ Verwende: presets export <datei> | presets import <datei>",
+		"error_exporting_presets":   "❌ Fehler beim Exportieren der Presets",
+		"error_importing_presets":   "❌ Fehler beim Importieren der Presets",
+		"presets_exported":          "💾 %d Presets nach %s exportiert",
+		"presets_imported":          "📥 Import: %d hinzugefügt, %d unverändert, %d Konflikte, %d fehlgeschlagen",
+		"presets_conflicts":         "⚠️ Konflikte (bestehende Presets beibehalten):",
+		"presets_failed":            "❌ Fehlgeschlagen:",
+		"sleep_usage":               "❌ Verwende: sleep <Minuten|30m|1h30m> | sleep off | sleep status",
+		"sleep_set":                 "💤 Sleep-Timer auf %s gesetzt",
+		"sleep_set_app":             "💤 Sleep-Timer auf %s gesetzt (blendet aus und stoppt, solange die App läuft)",
+		"sleep_off":                 "💤 Sleep-Timer abgebrochen",
+		"sleep_none":                "💤 Kein Sleep-Timer gesetzt",
+		"sleep_remaining":           "💤 Sleep-Timer: noch %s",
+		"error_sleep_timer":         "❌ Fehler beim Setzen des Sleep-Timers",
+		"fade_usage":                "❌ Verwende: fade <0-100> <Dauer> [linear|curved] | fade stop | stop --fade <Dauer>",
+		"fade_cancelled":            "🔉 Überblendung abgebrochen",
+		"fading_volume":             "🔉 Blende Lautstärke über %[2]s auf %[1]d%%",
+		"fading_out":                "🔉 Blende über %s aus und stoppe dann",
+		"next_alarm":                "⏰ Nächster Wecker: %s - %s",
+		"alarms_title":              "⏰ Wecker:",
+		"no_alarms":                 "⏰ Keine Wecker gestellt",
+		"alarms_not_supported":      "❌ Dieser Player unterstützt keine Wecker",
+		"error_alarm":               "❌ Wecker-Fehler",
+		"alarm_usage":               "❌ Verwende: alarm [list] | alarm add <HH:MM> <Tage> <Preset> [Lautstärke] | alarm remove|enable|disable <ID>",
+		"alarm_added":               "⏰ Wecker für %s gestellt (%s)",
+		"alarm_removed":             "⏰ Wecker %d entfernt",
+		"alarm_toggled":             "⏰ Wecker %d %s geschaltet",
+		"error_scene":               "❌ Szenen-Fehler",
+		"no_scenes":                 "🎬 Keine Szenen definiert (siehe scenes.json)",
+		"scenes_title":              "🎬 Szenen:",
+		"scene_not_found":           "❌ Szene '%s' nicht gefunden",
+		"scene_applied":             "🎬 Szene '%s' angewendet",
+		"web_title":                 "Multi-Room Audio",
+		"web_players":               "Player",
+		"web_group_hint":            "Ziehe einen Player auf einen anderen, um sie zu gruppieren. Klicke auf einen Player, um seine Presets zu sehen.",
+		"web_presets":               "Presets",
+		"web_no_presets":            "Keine Presets gefunden",
+		"web_loading":               "Lädt...",
+		"web_nothing_playing":       "Keine Wiedergabe",
+		"web_offline":               "Offline",
+		"web_state_playing":         "Spielt",
+		"web_state_paused":          "Pausiert",
+		"web_state_stopped":         "Gestoppt",
+		"web_play":                  "Abspielen",
+		"web_pause":                 "Pause",
+		"web_stop":                  "Stopp",
+		"web_next":                  "Nächster Titel",
+		"web_prev":                  "Vorheriger Titel",
+		"web_ungroup":               "Gruppe auflösen",
+		"web_volume":                "Lautstärke",
+		"web_connected":             "🟢 Live",
+		"web_disconnected":          "🔴 Verbindung verloren, verbinde neu...",
+		"history_title":             "📜 Zuletzt gespielt:",
+		"no_history":                "📜 Noch kein Wiedergabeverlauf (wird im Serve- oder Daemon-Modus aufgezeichnet)",
+		"error_history":             "❌ Verlaufs-Fehler",
+		"history_usage":             "❌ Verwendung: history [Anzahl Titel]",
+		"stats_usage":               "❌ Verwendung: stats [Tage]",
+		"stats_title":               "📊 Letzte %d Tage: %d Titel, %s",
+		"stats_top_artists":         "🎤 Top-Künstler:",
+		"stats_rooms":               "🏠 Räume:",
 	},
 	LangSwahili: {
-		"title":                   "🎵 Kidhibiti cha Audio ya Multi-Room",
-		"scanning":                "🔍 Kutafuta vichezaji vya audio kwenye mtandao...",
-		"scanning_network":        "   Kutafuta mtandao: %s",
-		"scanning_interface":      "   Interface %s: %s",
-		"found_player":            "   ✅ Kumepatikana: %s (%s) kwa %s",
-		"no_players":              "hakuna vichezaji vya audio vilivopatikana",
-		"could_not_determine_ip":  "haikuweza kutambua IP ya ndani: %w",
-		"available_players":       "📱 Vichezaji Vinavyopatikana:",
-		"select_player":           "Chagua kichezaji (1-%d): ",
-		"invalid_selection":       "❌ Chaguo batili",
-		"connected_to":            "✅ Imeunganishwa na: %s (%s)",
-		"error_selecting_player":  "Hitilafu katika kuchagua kichezaji: %v",
-		"interactive_mode":        "🎵 Kidhibiti cha Audio ya Multi-Room - Hali ya Maingiliano",
-		"separator":               "===========================================",
-		"status_volume":           "📊 Hali: %s | Sauti: %s",
-		"volume_unknown":          "N/A",
-		"error_retrieving_status": "❌ Hitilafu katika kupata hali",
-		"available_presets":       "📋 Mipangilio/Vipendwa Vinavyopatikana:",
-		"error_loading_presets":   "❌ Hitilafu katika kupakia mipangilio/vipendwa",
-		"available_commands":      "🎮 Amri Zinazopatikana:",
-		"cmd_play_preset":         "play <id>   - Cheza mpangilio/kipendwa",
-		"cmd_play":                "play       - Anza kucheza",
-		"cmd_pause":               "pause      - Simamisha",
-		"cmd_stop":                "stop       - Acha",
-		"cmd_next":                "next       - Wimbo ujao",
-		"cmd_prev":                "prev       - Wimbo uliopita",
-		"cmd_volume":              "vol <0-100> - Weka sauti",
-		"cmd_status":              "status     - Onyesha hali",
-		"cmd_presets":             "presets    - Onyesha mipangilio/vipendwa",
-		"cmd_help":                "help       - Onyesha msaada",
-		"cmd_lang":                "lang <en|de|sw> - Badilisha lugha",
-		"cmd_output":              "output <id> - Badili kichezaji",
-		"cmd_group":               "group <id1+id2> - Unganisha vichezaji",
-		"cmd_ungroup":             "ungroup - Ondoa vikundi vyote",
-		"cmd_debug":               "debug - Onyesha API endpoints",
-		"cmd_quit":                "quit/exit  - Toka programu",
-		"prompt":                  "Amri> ",
-		"invalid_preset_id":       "❌ Kitambulisho cha mpangilio/kipendwa si halali",
-		"error_playing_preset":    "❌ Hitilafu katika kucheza mpangilio/kipendwa",
-		"playing_preset":          "✅ Kucheza mpangilio/kipendwa %d",
-		"error_starting_playback": "❌ Hitilafu katika kuanza kucheza",
-		"playback_started":        "▶️ Imeanza kucheza",
-		"error_pausing":           "❌ Hitilafu katika kusimamisha",
-		"paused":                  "⏸️ Imesimamishwa",
-		"error_stopping":          "❌ Hitilafu katika kuacha",
-		"stopped":                 "⏹️ Imeachwa",
-		"error_next_track":        "❌ Hitilafu katika kuruka wimbo ujao",
-		"next_track":              "⏭️ Wimbo ujao",
-		"error_prev_track":        "❌ Hitilafu katika kurudi wimbo uliopita",
-		"prev_track":              "⏮️ Wimbo uliopita",
-		"volume_missing":          "❌ Thamani ya sauti inakosekana",
-		"invalid_volume":          "❌ Thamani ya sauti si halali",
-		"error_setting_volume":    "❌ Hitilafu katika kuweka sauti",
-		"volume_set":              "🔊 Sauti imewekwa %d%%",
-		"language_changed":        "🌍 Lugha imebadilishwa kuwa",
-		"invalid_language":        "❌ Lugha si halali. Tumia: en, de, sw",
-		"goodbye":                 "👋 Kwaheri!",
-		"unknown_command":         "❌ Amri isiyojulikana: %s (Andika 'help' kwa msaada)",
-		"last_action":             "Kitendo cha Mwisho:",
-		"no_song_playing":         "Hakuna wimbo unaochezwa",
-		"available_outputs":       "📱 Vichezaji Vinavyopatikana:",
-		"current_player":          "Kichezaji cha Sasa:",
-		"switched_to_player":      "🔄 Imebadilishwa kwa kichezaji %d: %s",
-		"invalid_player_id":       "❌ Kitambulisho cha kichezaji si halali",
-		"error_switching_player":  "❌ Hitilafu katika kubadili kichezaji",
-		"grouped_players":         "🔗 Vichezaji vimeunganishwa: %s kama mkuu",
-		"invalid_group_format":    "❌ Muundo wa kikundi si halali. Tumia: group <id1+id2>",
-		"error_grouping":          "❌ Hitilafu katika kuunganisha",
-		"group_combinations":      "🎵 Miunganiko ya Vikundi:",
-		"ungrouped_all":           "🔓 Vikundi vyote vya vichezaji vimeondolewa",
-		"error_ungrouping":        "❌ Hitilafu katika kuondoa vikundi",
-		"scanning_interfaces":     "🔍 Kumepatikana %d network interfaces za kutafuta",
-		"completed_scan":          "✅ Imemaliza kutafuta %d mitandao",
+		"title":                     "🎵 Kidhibiti cha Audio ya Multi-Room",
+		"scanning":                  "🔍 Kutafuta vichezaji vya audio kwenye mtandao...",
+		"scanning_network":          "   Kutafuta mtandao: %s",
+		"scanning_interface":        "   Interface %s: %s",
+		"found_player":              "   ✅ Kumepatikana: %s (%s) kwa %s",
+		"no_players":                "hakuna vichezaji vya audio vilivopatikana",
+		"could_not_determine_ip":    "haikuweza kutambua IP ya ndani: %w",
+		"available_players":         "📱 Vichezaji Vinavyopatikana:",
+		"select_player":             "Chagua kichezaji (1-%d): ",
+		"invalid_selection":         "❌ Chaguo batili",
+		"connected_to":              "✅ Imeunganishwa na: %s (%s)",
+		"error_selecting_player":    "Hitilafu katika kuchagua kichezaji: %v",
+		"interactive_mode":          "🎵 Kidhibiti cha Audio ya Multi-Room - Hali ya Maingiliano",
+		"separator":                 "===========================================",
+		"status_volume":             "📊 Hali: %s | Sauti: %s",
+		"volume_unknown":            "N/A",
+		"error_retrieving_status":   "❌ Hitilafu katika kupata hali",
+		"available_presets":         "📋 Mipangilio/Vipendwa Vinavyopatikana:",
+		"error_loading_presets":     "❌ Hitilafu katika kupakia mipangilio/vipendwa",
+		"available_commands":        "🎮 Amri Zinazopatikana:",
+		"cmd_play_preset":           "play <id>   - Cheza mpangilio/kipendwa",
+		"cmd_play":                  "play       - Anza kucheza",
+		"cmd_pause":                 "pause      - Simamisha",
+		"cmd_stop":                  "stop       - Acha",
+		"cmd_next":                  "next       - Wimbo ujao",
+		"cmd_prev":                  "prev       - Wimbo uliopita",
+		"cmd_volume":                "vol <0-100> - Weka sauti",
+		"cmd_status":                "status     - Onyesha hali",
+		"cmd_presets":               "presets    - Onyesha mipangilio/vipendwa",
+		"cmd_help":                  "help       - Onyesha msaada",
+		"cmd_lang":                  "lang <en|de|sw> - Badilisha lugha",
+		"cmd_output":                "output <id> - Badili kichezaji",
+		"cmd_group":                 "group <id1+id2> - Unganisha vichezaji",
+		"cmd_ungroup":               "ungroup - Ondoa vikundi vyote",
+		"cmd_debug":                 "debug - Onyesha API endpoints",
+		"cmd_quit":                  "quit/exit  - Toka programu",
+		"prompt":                    "Amri> ",
+		"invalid_preset_id":         "❌ Kitambulisho cha mpangilio/kipendwa si halali",
+		"error_playing_preset":      "❌ Hitilafu katika kucheza mpangilio/kipendwa",
+		"playing_preset":            "✅ Kucheza mpangilio/kipendwa %d",
+		"error_starting_playback":   "❌ Hitilafu katika kuanza kucheza",
+		"playback_started":          "▶️ Imeanza kucheza",
+		"error_pausing":             "❌ Hitilafu katika kusimamisha",
+		"paused":                    "⏸️ Imesimamishwa",
+		"error_stopping":            "❌ Hitilafu katika kuacha",
+		"stopped":                   "⏹️ Imeachwa",
+		"error_next_track":          "❌ Hitilafu katika kuruka wimbo ujao",
+		"next_track":                "⏭️ Wimbo ujao",
+		"error_prev_track":          "❌ Hitilafu katika kurudi wimbo uliopita",
+		"prev_track":                "⏮️ Wimbo uliopita",
+		"volume_missing":            "❌ Thamani ya sauti inakosekana",
+		"invalid_volume":            "❌ Thamani ya sauti si halali",
+		"error_setting_volume":      "❌ Hitilafu katika kuweka sauti",
+		"volume_set":                "🔊 Sauti imewekwa %d%%",
+		"language_changed":          "🌍 Lugha imebadilishwa kuwa",
+		"invalid_language":          "❌ Lugha si halali. Tumia: en, de, sw",
+		"goodbye":                   "👋 Kwaheri!",
+		"unknown_command":           "❌ Amri isiyojulikana: %s (Andika 'help' kwa msaada)",
+		"last_action":               "Kitendo cha Mwisho:",
+		"no_song_playing":           "Hakuna wimbo unaochezwa",
+		"available_outputs":         "📱 Vichezaji Vinavyopatikana:",
+		"current_player":            "Kichezaji cha Sasa:",
+		"switched_to_player":        "🔄 Imebadilishwa kwa kichezaji %d: %s",
+		"invalid_player_id":         "❌ Kitambulisho cha kichezaji si halali",
+		"error_switching_player":    "❌ Hitilafu katika kubadili kichezaji",
+		"grouped_players":           "🔗 Vichezaji vimeunganishwa: %s kama mkuu",
+		"invalid_group_format":      "❌ Muundo wa kikundi si halali. Tumia: group <id1+id2>",
+		"error_grouping":            "❌ Hitilafu katika kuunganisha",
+		"group_combinations":        "🎵 Miunganiko ya Vikundi:",
+		"ungrouped_all":             "🔓 Vikundi vyote vya vichezaji vimeondolewa",
+		"error_ungrouping":          "❌ Hitilafu katika kuondoa vikundi",
+		"scanning_interfaces":       "🔍 Kumepatikana %d network interfaces za kutafuta",
+		"completed_scan":            "✅ Imemaliza kutafuta %d mitandao",
+		"cmd_playurl":               "playurl <url> [jina] - Cheza URL ya stream",
+		"url_missing":               "❌ URL ya stream haipo",
+		"playing_url":               "📻 Inacheza stream: %s",
+		"error_playing_url":         "❌ Hitilafu katika kucheza stream",
+		"queue_title":               "📜 Foleni:",
+		"queue_empty":               "Foleni ni tupu",
+		"queue_more":                "  ... %d zaidi",
+		"error_loading_queue":       "❌ Hitilafu katika kupakia foleni",
+		"queue_refreshed":           "📜 Foleni imesasishwa",
+		"queue_usage":               "❌ Tumia: queue [add|next <url>] [rm <n>] [mv <kutoka> <hadi>] [play <n>] [clear] [save <jina>]",
+		"queue_added":               "➕ Imeongezwa kwenye foleni",
+		"queue_removed":             "➖ Wimbo %d umeondolewa kwenye foleni",
+		"queue_moved":               "↕️ Wimbo %d umehamishiwa nafasi %d",
+		"queue_cleared":             "🗑️ Foleni imefutwa",
+		"queue_playing":             "▶️ Inacheza wimbo %d wa foleni",
+		"queue_saved":               "💾 Foleni imehifadhiwa kama orodha: %s",
+		"error_queue":               "❌ Operesheni ya foleni imeshindwa",
+		"invalid_queue_index":       "❌ Nafasi ya foleni si halali",
+		"browse_title":              "📂 Vinjari:",
+		"browse_root":               "Mwanzo",
+		"browse_empty":              "(tupu)",
+		"browse_more_available":     "... vipengee zaidi vinapatikana (andika 'more')",
+		"browse_not_supported":      "❌ Kuvinjari hakutumiki kwenye kichezaji hiki",
+		"browse_not_open":           "❌ Kivinjari hakijafunguliwa (andika 'browse')",
+		"invalid_browse_item":       "❌ Nambari ya kipengee si halali",
+		"error_browsing":            "❌ Hitilafu katika kuvinjari",
+		"browse_opened":             "📂 Kivinjari kimefunguliwa",
+		"browse_entered":            "📂 Imefunguliwa: %s",
+		"browse_not_container":      "❌ Kipengee hiki hakiwezi kufunguliwa",
+		"browse_up":                 "⬆️ Rudi kiwango kilichopita",
+		"browse_closed":             "📂 Kivinjari kimefungwa",
+		"browse_no_more":            "❌ Hakuna vipengee zaidi",
+		"browse_next_page":          "📄 Ukurasa unaofuata",
+		"browse_queued":             "➕ Imeongezwa kwenye foleni: %s",
+		"browse_playing":            "▶️ Inacheza: %s",
+		"search_missing":            "❌ Maandishi ya kutafuta hayapo",
+		"error_searching":           "❌ Hitilafu katika kutafuta",
+		"search_results":            "🔍 Matokeo %d ya '%s' (bplay <n> / bqueue <n>)",
+		"search_source_presets":     "Preset",
+		"no_preset_match":           "❌ Hakuna preset/kipendwa kinacholingana na '%s'",
+		"ambiguous_preset":          "❓ Presets/vipendwa kadhaa vinalingana na '%s':",
+		"select_preset":             "Chagua preset (1-%d): ",
+		"selection_cancelled":       "❌ Uchaguzi umesitishwa",
+		"preset_usage":              "❌ Tumia: preset save [nafasi] [jina] | rename <id> <jina> | delete <id> | move <kutoka> <hadi>",
+		"preset_edit_not_supported": "❌ Presets haziwezi kuhaririwa kwenye kichezaji hiki",
+		"preset_saved":              "💾 Preset imehifadhiwa: %s",
+		"preset_renamed":            "✏️ Preset %d imebadilishwa jina kuwa %s",
+		"preset_deleted":            "🗑️ Preset %d imefutwa",
+		"preset_moved":              "↕️ Preset %d imehamishiwa nafasi %d",
+		"error_editing_preset":      "❌ Hitilafu katika kuhariri presets",
+		"library_title":             "📚 Maktaba ya Presets:",
+		"error_library":             "❌ Hitilafu ya maktaba ya presets",
+		"library_info":              "📚 Maktaba ya presets: vipengee %d, hali ya maktaba %s",
+		"library_mode":              "📚 Hali ya maktaba %s",
+		"library_usage":             "❌ Tumia: lib [on|off] | add <url> <jina> | rm <id> | tag <id> <tags> | play <id> | push <id> [nafasi] | import",
+		"library_added":             "📚 Kipengee %d kimeongezwa kwenye maktaba: %s",
+		"library_removed":           "🗑️ Kipengee %d kimeondolewa kwenye maktaba",
+		"library_tagged":            "🏷️ Tags za %s: %s",
+		"library_imported":          "📥 Presets %d zimeingizwa kwenye maktaba (%d zimerukwa)",
+		"library_pushed":            "📤 %s imetumwa kwa kichezaji",
+		"presets_transfer_usage":    "❌ Tumia: presets export <faili> | presets import <faili>",
+		"error_exporting_presets":   "❌ Hitilafu katika kuhamisha presets",
+		"error_importing_presets":   "❌ Hitilafu katika kuingiza presets",
+		"presets_exported":          "💾 Presets %d zimehamishwa kwenda %s",
+		"presets_imported":          "📥 Uingizaji: %d zimeongezwa, %d hazijabadilika, migongano %d, %d zimeshindwa",
+		"presets_conflicts":         "⚠️ Migongano (presets zilizopo zimebaki):",
+		"presets_failed":            "❌ Zimeshindwa:",
+		"sleep_usage":               "❌ Tumia: sleep <dakika|30m|1h30m> | sleep off | sleep status",
+		"sleep_set":                 "💤 Kipima muda wa kulala kimewekwa %s",
+		"sleep_set_app":             "💤 Kipima muda wa kulala kimewekwa %s (kinapunguza sauti na kusimamisha wakati programu inaendelea)",
+		"sleep_off":                 "💤 Kipima muda wa kulala kimeghairiwa",
+		"sleep_none":                "💤 Hakuna kipima muda wa kulala",
+		"sleep_remaining":           "💤 Kipima muda wa kulala: zimebaki %s",
+		"error_sleep_timer":         "❌ Hitilafu katika kuweka kipima muda wa kulala",
+		"fade_usage":                "❌ Tumia: fade <0-100> <muda> [linear|curved] | fade stop | stop --fade <muda>",
+		"fade_cancelled":            "🔉 Ufifishaji umeghairiwa",
+		"fading_volume":             "🔉 Inabadilisha sauti hadi %d%% kwa %s",
+		"fading_out":                "🔉 Inapunguza sauti kwa %s, kisha inasimamisha",
+		"next_alarm":                "⏰ Kengele kijacho: %s - %s",
+		"alarms_title":              "⏰ Kengele:",
+		"no_alarms":                 "⏰ Hakuna kengele zilizowekwa",
+		"alarms_not_supported":      "❌ Kichezaji hiki hakiauni kengele",
+		"error_alarm":               "❌ Hitilafu ya kengele",
+		"alarm_usage":               "❌ Tumia: alarm [list] | alarm add <HH:MM> <siku> <preset> [sauti] | alarm remove|enable|disable <id>",
+		"alarm_added":               "⏰ Kengele imewekwa %s (%s)",
+		"alarm_removed":             "⏰ Kengele %d imeondolewa",
+		"alarm_toggled":             "⏰ Kengele %d imewashwa/imezimwa: %s",
+		"error_scene":               "❌ Hitilafu ya onyesho",
+		"no_scenes":                 "🎬 Hakuna maonyesho yaliyofafanuliwa (angalia scenes.json)",
+		"scenes_title":              "🎬 Maonyesho:",
+		"scene_not_found":           "❌ Onyesho '%s' halikupatikana",
+		"scene_applied":             "🎬 Onyesho '%s' limetumika",
+		"web_title":                 "Audio ya Multi-Room",
+		"web_players":               "Vichezaji",
+		"web_group_hint":            "Buruta kichezaji juu ya kingine ili kuviweka kwenye kikundi. Bofya kichezaji ili kuona preset zake.",
+		"web_presets":               "Preset",
+		"web_no_presets":            "Hakuna preset zilizopatikana",
+		"web_loading":               "Inapakia...",
+		"web_nothing_playing":       "Hakuna kinachocheza",
+		"web_offline":               "Nje ya mtandao",
+		"web_state_playing":         "Inacheza",
+		"web_state_paused":          "Imesimamishwa",
+		"web_state_stopped":         "Imeachwa",
+		"web_play":                  "Cheza",
+		"web_pause":                 "Simamisha",
+		"web_stop":                  "Acha",
+		"web_next":                  "Wimbo unaofuata",
+		"web_prev":                  "Wimbo uliopita",
+		"web_ungroup":               "Vunja kikundi",
+		"web_volume":                "Sauti",
+		"web_connected":             "🟢 Moja kwa moja",
+		"web_disconnected":          "🔴 Muunganisho umepotea, inaunganisha tena...",
+		"history_title":             "📜 Zilizochezwa hivi karibuni:",
+		"no_history":                "📜 Bado hakuna historia ya uchezaji (hurekodiwa wakati hali ya serve au daemon inaendeshwa)",
+		"error_history":             "❌ Hitilafu ya historia",
+		"history_usage":             "❌ Matumizi: history [idadi ya nyimbo]",
+		"stats_usage":               "❌ Matumizi: stats [siku]",
+		"stats_title":               "📊 Siku %d zilizopita: nyimbo %d, %s",
+		"stats_top_artists":         "🎤 Wasanii bora:",
+		"stats_rooms":               "🏠 Vyumba:",
 	},
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -17,13 +18,26 @@ type TUIState struct {
 	status           *Status
 	presets          []Preset
 	lastAction       string
+	lastOutcome      commandOutcome
 	statusError      string
 	presetsError     string
+	queue            []QueueItem
+	queueError       string
+	browseStack      []*BrowseResult
+	library          *Library
 	availablePlayers []PlayerInfo
+	sleepRemaining   time.Duration
+	alarms           []Alarm
 }
 
 var tuiState = &TUIState{}
 
+// Shared stdin reader so prompts don't lose buffered input
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// False in daemon mode, where commands must never wait for input
+var interactive = true
+
 // Clear screen and move cursor to top
 func clearScreen() {
 	fmt.Print("\033[2J\033[H")
@@ -39,9 +53,16 @@ func updateStatus() {
 		tuiState.status = status
 		tuiState.statusError = ""
 	}
+	tuiState.sleepRemaining = sleepRemaining()
 }
 
 func updatePresets() {
+	if libraryActive() {
+		tuiState.presets = tuiState.library.Presets()
+		tuiState.presetsError = ""
+		return
+	}
+
 	presets, err := tuiState.client.GetPresets()
 	if err != nil {
 		tuiState.presetsError = getText("error_loading_presets")
@@ -52,6 +73,34 @@ func updatePresets() {
 	}
 }
 
+// Point the TUI commands at another player. Its presets are loaded again on
+// demand, so preset names don't resolve against the previous player.
+func useClient(client AudioClient, playerName string) {
+	tuiState.client = client
+	tuiState.playerName = playerName
+	tuiState.presets = nil
+	tuiState.presetsError = ""
+}
+
+// How the last command ended, for callers that can't just show lastAction
+type commandOutcome int
+
+const (
+	outcomeOK commandOutcome = iota
+	outcomeFailed
+	outcomeUsage // bad arguments, unknown command or ambiguous name
+)
+
+func reportError(message string) {
+	tuiState.lastAction = message
+	tuiState.lastOutcome = outcomeFailed
+}
+
+func reportUsage(message string) {
+	tuiState.lastAction = message
+	tuiState.lastOutcome = outcomeUsage
+}
+
 // Render the complete TUI
 func renderTUI() {
 	clearScreen()
@@ -69,6 +118,7 @@ func renderTUI() {
 		}
 	}
 	fmt.Printf("🔗 %s %s%s\n", getText("current_player"), tuiState.playerName, deviceTypeIndicator)
+	renderNextAlarm()
 	fmt.Println()
 
 	// Available Players Section
@@ -112,7 +162,11 @@ func renderTUI() {
 		if tuiState.status.Volume >= 0 {
 			volumeStr = fmt.Sprintf("%d%%", tuiState.status.Volume)
 		}
-		fmt.Printf(getText("status_volume")+"\n", tuiState.status.State, volumeStr)
+		fmt.Printf(getText("status_volume"), tuiState.status.State, volumeStr)
+		if tuiState.sleepRemaining > 0 {
+			fmt.Printf(" | "+getText("sleep_remaining"), formatSleepDuration(tuiState.sleepRemaining))
+		}
+		fmt.Println()
 		if tuiState.status.Song != "" {
 			fmt.Printf("🎵 %s", tuiState.status.Song)
 			if tuiState.status.Artist != "" {
@@ -129,20 +183,38 @@ func renderTUI() {
 	fmt.Println()
 
 	// Presets Section
-	fmt.Println(getText("available_presets"))
+	if libraryActive() {
+		fmt.Println(getText("library_title"))
+	} else {
+		fmt.Println(getText("available_presets"))
+	}
 	if tuiState.presetsError != "" {
 		fmt.Println(tuiState.presetsError)
 	} else if tuiState.presets != nil {
 		for _, preset := range tuiState.presets {
-			fmt.Printf("  [%d] %s\n", preset.ID, preset.Name)
+			fmt.Printf("  [%d] %s%s\n", preset.ID, preset.Name, libraryTags(preset.ID))
 		}
 	}
 	fmt.Println()
 
+	// Queue Section
+	renderQueue()
+
+	// Browse Section
+	renderBrowser()
+
 	// Commands Section - Display in compact rows
 	fmt.Println(getText("available_commands"))
-	fmt.Println("  play <id> | play | pause | stop | next | prev | vol <0-100>")
-	fmt.Println("  output <id> | group <id1+id2> | ungroup | lang <en|de|sw> | quit")
+	fmt.Println("  play <id|name> | playurl <url> | play | pause | stop | next | prev | vol <0-100>")
+	fmt.Println("  preset save [slot] [name] | preset rename <id> <name> | preset delete <id> | preset move <a> <b>")
+	fmt.Println("  presets export <file> | presets import <file>  (.json .xml .m3u .pls .xspf)")
+	fmt.Println("  lib [on|off] | lib add <url> <name> | lib rm|tag|play|push <id> | lib import")
+	fmt.Println("  queue [add|next <url> | rm <n> | mv <a> <b> | play <n> | clear | save <name>]")
+	fmt.Println("  browse | search <text> | cd <n> | up | more | bplay <n> | bqueue <n> | browse close")
+	fmt.Println("  alarm [list] | alarm add <HH:MM> <daily|weekdays|mon-fri|once> <preset> [vol] | alarm remove|enable|disable <id>")
+	fmt.Println("  stop --fade <10s> | fade <0-100> <duration> [linear|curved] | fade stop | sleep <30m|off|status>")
+	fmt.Println("  history [n] | stats [days]")
+	fmt.Println("  scene [name] | output <id> | group <id1+id2> | ungroup | lang <en|de|sw> | quit")
 	fmt.Println()
 
 	// Last Action
@@ -155,6 +227,26 @@ func renderTUI() {
 	fmt.Println(strings.Repeat("=", 70))
 }
 
+func newClientForPlayer(player PlayerInfo) (AudioClient, error) {
+	switch player.Type {
+	case DeviceTypeBluOS:
+		return NewBluesoundClient(player.IP), nil
+	case DeviceTypeSonos:
+		return NewSonosClient(player.IP), nil
+	}
+	return nil, fmt.Errorf("unsupported device type")
+}
+
+// Find a player by name (case-insensitive) or IP address
+func findPlayer(players []PlayerInfo, spec string) (PlayerInfo, bool) {
+	for _, player := range players {
+		if player.IP == spec || strings.EqualFold(player.Name, spec) {
+			return player, true
+		}
+	}
+	return PlayerInfo{}, false
+}
+
 // Player selection
 func selectPlayer() (AudioClient, string, []PlayerInfo, error) {
 	players, err := scanForPlayers()
@@ -163,7 +255,7 @@ func selectPlayer() (AudioClient, string, []PlayerInfo, error) {
 	}
 
 	if len(players) == 0 {
-		return nil, "", nil, fmt.Errorf(getText("no_players"))
+		return nil, "", nil, errors.New(getText("no_players"))
 	}
 
 	fmt.Println("\n" + getText("available_players"))
@@ -178,10 +270,9 @@ func selectPlayer() (AudioClient, string, []PlayerInfo, error) {
 		fmt.Printf("  [%d] %s (%s %s) - %s%s\n", i+1, player.Name, player.Brand, player.Model, player.IP, typeIndicator)
 	}
 
-	reader := bufio.NewReader(os.Stdin)
 	for {
 		fmt.Printf("\n"+getText("select_player"), len(players))
-		input, _ := reader.ReadString('\n')
+		input, _ := stdinReader.ReadString('\n')
 		input = strings.TrimSpace(input)
 
 		choice, err := strconv.Atoi(input)
@@ -193,14 +284,9 @@ func selectPlayer() (AudioClient, string, []PlayerInfo, error) {
 		selectedPlayer := players[choice-1]
 		fmt.Printf(getText("connected_to")+"\n", selectedPlayer.Name, selectedPlayer.IP)
 
-		var client AudioClient
-		switch selectedPlayer.Type {
-		case DeviceTypeBluOS:
-			client = NewBluesoundClient(selectedPlayer.IP)
-		case DeviceTypeSonos:
-			client = NewSonosClient(selectedPlayer.IP)
-		default:
-			return nil, "", nil, fmt.Errorf("unsupported device type")
+		client, err := newClientForPlayer(selectedPlayer)
+		if err != nil {
+			return nil, "", nil, err
 		}
 
 		return client, selectedPlayer.Name, players, nil
@@ -210,35 +296,35 @@ func selectPlayer() (AudioClient, string, []PlayerInfo, error) {
 // Switch to different player
 func switchToPlayer(playerID int) {
 	if playerID < 1 || playerID > len(tuiState.availablePlayers) {
-		tuiState.lastAction = getText("invalid_player_id")
+		reportUsage(getText("invalid_player_id"))
 		return
 	}
 
 	selectedPlayer := tuiState.availablePlayers[playerID-1]
 
-	switch selectedPlayer.Type {
-	case DeviceTypeBluOS:
-		tuiState.client = NewBluesoundClient(selectedPlayer.IP)
-	case DeviceTypeSonos:
-		tuiState.client = NewSonosClient(selectedPlayer.IP)
-	default:
-		tuiState.lastAction = getText("error_switching_player")
+	client, err := newClientForPlayer(selectedPlayer)
+	if err != nil {
+		reportError(getText("error_switching_player"))
 		return
 	}
+	tuiState.client = client
 
 	tuiState.playerName = selectedPlayer.Name
+	tuiState.browseStack = nil
 	tuiState.lastAction = fmt.Sprintf(getText("switched_to_player"), playerID, selectedPlayer.Name)
 
-	// Update status and presets for new player
+	// Update status, presets, queue and alarms for new player
 	updateStatus()
 	updatePresets()
+	updateQueue()
+	updateAlarms()
 }
 
 // Group players (only works for BluOS devices)
 func groupPlayers(groupSpec string) {
 	parts := strings.Split(groupSpec, "+")
 	if len(parts) != 2 {
-		tuiState.lastAction = getText("invalid_group_format")
+		reportUsage(getText("invalid_group_format"))
 		return
 	}
 
@@ -247,12 +333,12 @@ func groupPlayers(groupSpec string) {
 
 	if err1 != nil || err2 != nil || masterID < 1 || slaveID < 1 ||
 		masterID > len(tuiState.availablePlayers) || slaveID > len(tuiState.availablePlayers) {
-		tuiState.lastAction = getText("invalid_group_format")
+		reportUsage(getText("invalid_group_format"))
 		return
 	}
 
 	if masterID == slaveID {
-		tuiState.lastAction = getText("invalid_group_format")
+		reportUsage(getText("invalid_group_format"))
 		return
 	}
 
@@ -261,17 +347,18 @@ func groupPlayers(groupSpec string) {
 
 	// Check if both are BluOS devices
 	if masterPlayer.Type != DeviceTypeBluOS || slavePlayer.Type != DeviceTypeBluOS {
-		tuiState.lastAction = "❌ Grouping only supported for BluOS devices"
+		reportError("❌ Grouping only supported for BluOS devices")
 		return
 	}
 
 	// Switch to master player
 	tuiState.client = NewBluesoundClient(masterPlayer.IP)
 	tuiState.playerName = masterPlayer.Name
+	tuiState.browseStack = nil
 
 	// Add slave to master
 	if err := tuiState.client.AddSlave(slavePlayer.IP); err != nil {
-		tuiState.lastAction = getText("error_grouping")
+		reportError(getText("error_grouping"))
 		return
 	}
 
@@ -296,7 +383,7 @@ func ungroupAll() {
 	}
 
 	if tuiState.client.GetDeviceType() != DeviceTypeBluOS {
-		tuiState.lastAction = "❌ Ungrouping only supported for BluOS devices"
+		reportError("❌ Ungrouping only supported for BluOS devices")
 		return
 	}
 
@@ -344,7 +431,7 @@ func ungroupAll() {
 	if successCount > 0 {
 		tuiState.lastAction = getText("ungrouped_all")
 	} else {
-		tuiState.lastAction = fmt.Sprintf("%s (RemoveSlave approach failed)", getText("error_ungrouping"))
+		reportError(fmt.Sprintf("%s (RemoveSlave approach failed)", getText("error_ungrouping")))
 	}
 
 	updateStatus()
@@ -363,165 +450,284 @@ func changeLanguage(lang string) {
 		currentLanguage = LangSwahili
 		tuiState.lastAction = getText("language_changed") + " Kiswahili"
 	default:
-		tuiState.lastAction = getText("invalid_language")
+		reportUsage(getText("invalid_language"))
 	}
 }
 
 // Interactive loop
 func interactiveMode() {
-	reader := bufio.NewReader(os.Stdin)
+	// Preset library is optional; without it the player's presets are used
+	tuiState.library, _ = loadLibrary()
 
 	// Initial data load
 	updateStatus()
 	updatePresets()
+	updateQueue()
+	updateAlarms()
+
+	// Rings the alarms of players without an alarm clock (BluOS)
+	startAlarmScheduler()
+
+	// Media keys and desktop widgets (Linux)
+	startMPRIS()
 
 	for {
+		player, _ := findPlayer(tuiState.availablePlayers, tuiState.playerName)
+		mprisSetPlayer(tuiState.client, player)
+
 		renderTUI()
 		fmt.Print(getText("prompt"))
 
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-
-		if input == "" {
-			continue
+		input, _ := stdinReader.ReadString('\n')
+		if !executeCommand(input) {
+			clearScreen()
+			fmt.Println(getText("goodbye"))
+			return
 		}
+	}
+}
 
-		parts := strings.Fields(input)
-		command := strings.ToLower(parts[0])
+// Commands executeCommand knows
+var tuiCommands = []string{
+	"play", "playurl", "pause", "stop", "next", "prev", "previous", "vol", "volume",
+	"status", "presets", "sleep", "fade", "alarm", "alarms", "scene", "scenes",
+	"history", "stats", "queue", "browse", "search", "cd", "up", "more", "bplay",
+	"bqueue", "preset", "lib", "library", "help", "output", "group", "ungroup",
+	"debug", "lang", "language", "quit", "exit",
+}
 
-		switch command {
-		case "play":
-			if len(parts) > 1 {
-				// Play preset/favorite
-				presetID, err := strconv.Atoi(parts[1])
-				if err != nil {
-					tuiState.lastAction = getText("invalid_preset_id")
-					continue
-				}
-				if err := tuiState.client.PlayPreset(presetID); err != nil {
-					tuiState.lastAction = fmt.Sprintf("%s: %v", getText("error_playing_preset"), err)
-				} else {
-					tuiState.lastAction = fmt.Sprintf(getText("playing_preset"), presetID)
-					time.Sleep(500 * time.Millisecond)
-					updateStatus()
-				}
-			} else {
-				// Start playback
-				if err := tuiState.client.Play(); err != nil {
-					tuiState.lastAction = getText("error_starting_playback")
-				} else {
-					tuiState.lastAction = getText("playback_started")
-					time.Sleep(500 * time.Millisecond)
-					updateStatus()
-				}
-			}
+// Run one command line as typed in the TUI. Results go to tuiState.lastAction
+// and lastOutcome; returns false when the user asked to quit.
+func executeCommand(input string) bool {
+	tuiState.lastOutcome = outcomeOK
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return true
+	}
 
-		case "pause":
-			if err := tuiState.client.Pause(); err != nil {
-				tuiState.lastAction = getText("error_pausing")
-			} else {
-				tuiState.lastAction = getText("paused")
-				updateStatus()
-			}
+	parts := strings.Fields(input)
+	command := strings.ToLower(parts[0])
 
-		case "stop":
-			if err := tuiState.client.Stop(); err != nil {
-				tuiState.lastAction = getText("error_stopping")
-			} else {
-				tuiState.lastAction = getText("stopped")
-				updateStatus()
+	switch command {
+	case "play":
+		if len(parts) > 1 {
+			// Play preset/favorite by number or by name
+			presetID, err := strconv.Atoi(parts[1])
+			if err != nil {
+				preset, ok := resolvePresetByName(strings.Join(parts[1:], " "))
+				if !ok {
+					return true
+				}
+				presetID = preset.ID
 			}
-
-		case "next":
-			if err := tuiState.client.Next(); err != nil {
-				tuiState.lastAction = getText("error_next_track")
+			if err := playPresetByID(presetID); err != nil {
+				reportError(fmt.Sprintf("%s: %v", getText("error_playing_preset"), err))
 			} else {
-				tuiState.lastAction = getText("next_track")
+				tuiState.lastAction = fmt.Sprintf(getText("playing_preset"), presetID)
 				time.Sleep(500 * time.Millisecond)
 				updateStatus()
 			}
-
-		case "prev", "previous":
-			if err := tuiState.client.Previous(); err != nil {
-				tuiState.lastAction = getText("error_prev_track")
+		} else {
+			// Start playback
+			if err := tuiState.client.Play(); err != nil {
+				reportError(getText("error_starting_playback"))
 			} else {
-				tuiState.lastAction = getText("prev_track")
+				tuiState.lastAction = getText("playback_started")
 				time.Sleep(500 * time.Millisecond)
 				updateStatus()
 			}
+		}
 
-		case "vol", "volume":
-			if len(parts) < 2 {
-				tuiState.lastAction = getText("volume_missing")
-				continue
-			}
-			volume, err := strconv.Atoi(parts[1])
-			if err != nil {
-				tuiState.lastAction = getText("invalid_volume")
-				continue
-			}
-			if err := tuiState.client.SetVolume(volume); err != nil {
-				tuiState.lastAction = getText("error_setting_volume")
-			} else {
-				tuiState.lastAction = fmt.Sprintf(getText("volume_set"), volume)
-				updateStatus()
+	case "playurl":
+		if len(parts) < 2 {
+			reportUsage(getText("url_missing"))
+			return true
+		}
+		title := strings.Join(parts[2:], " ")
+		if err := tuiState.client.PlayURL(parts[1], title); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_playing_url"), err))
+		} else {
+			if title == "" {
+				title = parts[1]
 			}
+			tuiState.lastAction = fmt.Sprintf(getText("playing_url"), title)
+			time.Sleep(500 * time.Millisecond)
+			updateStatus()
+		}
 
-		case "status":
+	case "pause":
+		if err := tuiState.client.Pause(); err != nil {
+			reportError(getText("error_pausing"))
+		} else {
+			tuiState.lastAction = getText("paused")
 			updateStatus()
-			tuiState.lastAction = "Status refreshed"
+		}
 
-		case "presets":
-			updatePresets()
-			tuiState.lastAction = "Presets/Favorites refreshed"
+	case "stop":
+		fadeDuration, _, ok := fadeOption(parts[1:])
+		if !ok {
+			reportUsage(getText("fade_usage"))
+			return true
+		}
+		if fadeDuration > 0 {
+			fadeOutAndStop(tuiState.client, fadeDuration)
+			tuiState.lastAction = fmt.Sprintf(getText("fading_out"), fadeDuration)
+			return true
+		}
+		cancelFade(tuiState.client)
+		if err := tuiState.client.Stop(); err != nil {
+			reportError(getText("error_stopping"))
+		} else {
+			tuiState.lastAction = getText("stopped")
+			updateStatus()
+		}
 
-		case "help":
-			tuiState.lastAction = "Help displayed above"
+	case "next":
+		if err := tuiState.client.Next(); err != nil {
+			reportError(getText("error_next_track"))
+		} else {
+			tuiState.lastAction = getText("next_track")
+			time.Sleep(500 * time.Millisecond)
+			updateStatus()
+		}
 
-		case "output":
-			if len(parts) < 2 {
-				tuiState.lastAction = getText("invalid_player_id")
-				continue
-			}
-			playerID, err := strconv.Atoi(parts[1])
-			if err != nil {
-				tuiState.lastAction = getText("invalid_player_id")
-				continue
-			}
-			switchToPlayer(playerID)
+	case "prev", "previous":
+		if err := tuiState.client.Previous(); err != nil {
+			reportError(getText("error_prev_track"))
+		} else {
+			tuiState.lastAction = getText("prev_track")
+			time.Sleep(500 * time.Millisecond)
+			updateStatus()
+		}
 
-		case "group":
-			if len(parts) < 2 {
-				tuiState.lastAction = getText("invalid_group_format")
-				continue
-			}
-			groupPlayers(parts[1])
+	case "vol", "volume":
+		if len(parts) < 2 {
+			reportUsage(getText("volume_missing"))
+			return true
+		}
+		volume, err := strconv.Atoi(parts[1])
+		if err != nil {
+			reportUsage(getText("invalid_volume"))
+			return true
+		}
+		// A manual change wins over a running fade
+		cancelFade(tuiState.client)
+		if err := tuiState.client.SetVolume(volume); err != nil {
+			reportError(getText("error_setting_volume"))
+		} else {
+			tuiState.lastAction = fmt.Sprintf(getText("volume_set"), volume)
+			updateStatus()
+		}
 
-		case "ungroup":
-			ungroupAll()
+	case "status":
+		updateStatus()
+		tuiState.lastAction = "Status refreshed"
 
-		case "debug":
-			debugAPI()
+	case "presets":
+		handlePresetsCommand(parts[1:])
 
-		case "lang", "language":
-			if len(parts) < 2 {
-				tuiState.lastAction = getText("invalid_language")
-				continue
-			}
-			changeLanguage(parts[1])
+	case "sleep":
+		handleSleepCommand(parts[1:])
 
-		case "quit", "exit":
-			clearScreen()
-			fmt.Println(getText("goodbye"))
-			return
+	case "fade":
+		handleFadeCommand(parts[1:])
+
+	case "alarm", "alarms":
+		handleAlarmCommand(parts[1:])
+
+	case "scene", "scenes":
+		handleSceneCommand(parts[1:])
+
+	case "history":
+		handleHistoryCommand(parts[1:])
+
+	case "stats":
+		handleStatsCommand(parts[1:])
+
+	case "queue":
+		handleQueueCommand(parts[1:])
+
+	case "browse":
+		handleBrowseCommand(parts[1:])
+
+	case "search":
+		runSearch(strings.Join(parts[1:], " "))
+
+	case "cd":
+		if len(parts) < 2 {
+			reportUsage(getText("invalid_browse_item"))
+			return true
+		}
+		browseInto(parts[1])
+
+	case "up":
+		browseUp()
+
+	case "more":
+		browseMore()
+
+	case "bplay", "bqueue":
+		if len(parts) < 2 {
+			reportUsage(getText("invalid_browse_item"))
+			return true
+		}
+		browsePlay(parts[1], command == "bqueue")
+
+	case "preset":
+		handlePresetCommand(parts[1:])
+
+	case "lib", "library":
+		handleLibraryCommand(parts[1:])
+
+	case "help":
+		tuiState.lastAction = "Help displayed above"
+
+	case "output":
+		if len(parts) < 2 {
+			reportUsage(getText("invalid_player_id"))
+			return true
+		}
+		playerID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			reportUsage(getText("invalid_player_id"))
+			return true
+		}
+		switchToPlayer(playerID)
+
+	case "group":
+		if len(parts) < 2 {
+			reportUsage(getText("invalid_group_format"))
+			return true
+		}
+		groupPlayers(parts[1])
+
+	case "ungroup":
+		ungroupAll()
 
-		default:
-			tuiState.lastAction = fmt.Sprintf(getText("unknown_command"), command)
+	case "debug":
+		debugAPI()
+
+	case "lang", "language":
+		if len(parts) < 2 {
+			reportUsage(getText("invalid_language"))
+			return true
 		}
+		changeLanguage(parts[1])
+
+	case "quit", "exit":
+		return false
+
+	default:
+		reportUsage(fmt.Sprintf(getText("unknown_command"), command))
 	}
+	return true
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCLI(os.Args[1:]))
+	}
+
 	fmt.Println(getText("title"))
 	fmt.Println(strings.Repeat("=", 70))
 
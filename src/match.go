@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Accented characters folded to their base letters for name matching
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "œ", "oe",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y", "ß", "ss",
+)
+
+// Lower-case, strip accents and reduce punctuation to single spaces
+func normalizeName(s string) string {
+	s = accentReplacer.Replace(strings.ToLower(s))
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// Score how well a name matches a query (0 = no match)
+func matchScore(name, query string) int {
+	n := normalizeName(name)
+	q := normalizeName(query)
+	if n == "" || q == "" {
+		return 0
+	}
+
+	compactName := strings.ReplaceAll(n, " ", "")
+	compactQuery := strings.ReplaceAll(q, " ", "")
+
+	switch {
+	case n == q:
+		return 100
+	case compactName == compactQuery:
+		return 95
+	case strings.HasPrefix(n, q):
+		return 90
+	case strings.Contains(" "+n+" ", " "+q+" "):
+		return 80
+	case strings.Contains(n, q) || strings.Contains(compactName, compactQuery):
+		return 70
+	}
+
+	// Every query word has to appear in the name, exactly or with a typo
+	nameWords := strings.Fields(n)
+	score := 60
+	for _, word := range strings.Fields(q) {
+		best := 0
+		for _, nameWord := range nameWords {
+			if strings.Contains(nameWord, word) {
+				best = 60
+				break
+			}
+			if levenshtein(nameWord, word) <= typoAllowance(word) {
+				best = 50
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		if best < score {
+			score = best
+		}
+	}
+	return score
+}
+
+func typoAllowance(word string) int {
+	switch n := len([]rune(word)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Presets matching the query, best first. Only the presets sharing the top
+// score are returned, so more than one result means the query is ambiguous.
+func matchPresets(presets []Preset, query string) []Preset {
+	type scored struct {
+		preset Preset
+		score  int
+	}
+
+	var matches []scored
+	for _, preset := range presets {
+		if strings.HasPrefix(preset.Name, "[INFO]") {
+			continue
+		}
+		if score := matchScore(preset.Name, query); score > 0 {
+			matches = append(matches, scored{preset, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	var best []Preset
+	for _, m := range matches {
+		if m.score < matches[0].score {
+			break
+		}
+		best = append(best, m.preset)
+	}
+	return best
+}
+
+// Resolve "play <name>" against the current presets, asking the user to
+// pick one when several presets match equally well.
+func resolvePresetByName(query string) (Preset, bool) {
+	if tuiState.presets == nil {
+		updatePresets()
+	}
+
+	matches := matchPresets(tuiState.presets, query)
+	switch len(matches) {
+	case 0:
+		reportError(fmt.Sprintf(getText("no_preset_match"), query))
+		return Preset{}, false
+	case 1:
+		return matches[0], true
+	}
+
+	if !interactive {
+		lines := []string{fmt.Sprintf(getText("ambiguous_preset"), query)}
+		for _, preset := range matches {
+			lines = append(lines, fmt.Sprintf("  [%d] %s", preset.ID, preset.Name))
+		}
+		reportUsage(strings.Join(lines, "\n"))
+		return Preset{}, false
+	}
+
+	fmt.Printf("\n"+getText("ambiguous_preset")+"\n", query)
+	for i, preset := range matches {
+		fmt.Printf("  [%d] %s\n", i+1, preset.Name)
+	}
+	fmt.Printf(getText("select_preset"), len(matches))
+
+	input, _ := stdinReader.ReadString('\n')
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || choice < 1 || choice > len(matches) {
+		reportError(getText("selection_cancelled"))
+		return Preset{}, false
+	}
+
+	return matches[choice-1], true
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prometheus metrics of the daemon (`daemon --metrics :9100`), written in the
+// text exposition format:
+//
+//	bluesoundplayer_player_up{player,ip,type}             1 while the player answers
+//	bluesoundplayer_player_volume{player,ip}              current volume
+//	bluesoundplayer_player_playing{player,ip}             1 while playing
+//	bluesoundplayer_request_duration_seconds{device,endpoint}   BluOS endpoint or Sonos SOAP action
+//	bluesoundplayer_request_errors_total{device,endpoint,type}  timeout, network, http_status, soap_fault, read
+//	bluesoundplayer_discovery_scan_duration_seconds       network scans
+//	bluesoundplayer_discovered_players                    players found by the last scan
+
+var (
+	requestBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	scanBuckets    = []float64{1, 2, 5, 10, 20, 30, 60, 120}
+)
+
+type histogram struct {
+	buckets []float64
+	counts  []uint64 // per bucket, the last one is +Inf
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets)+1)}
+}
+
+func (h *histogram) observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+type requestKey struct {
+	device, endpoint string
+}
+
+type requestErrorKey struct {
+	device, endpoint, kind string
+}
+
+type playerGauges struct {
+	player  PlayerInfo
+	up      bool
+	volume  int
+	playing bool
+}
+
+var metrics = struct {
+	mu         sync.Mutex
+	requests   map[requestKey]*histogram
+	errors     map[requestErrorKey]uint64
+	scans      *histogram
+	discovered int
+	players    map[string]*playerGauges // by IP
+}{
+	requests: make(map[requestKey]*histogram),
+	errors:   make(map[requestErrorKey]uint64),
+	scans:    newHistogram(scanBuckets),
+	players:  make(map[string]*playerGauges),
+}
+
+// Record a player API call; kind is the error type, empty on success
+func observeRequest(device, endpoint string, start time.Time, kind string) {
+	// Query strings would create a series per stream URL
+	endpoint, _, _ = strings.Cut(endpoint, "?")
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	key := requestKey{device, endpoint}
+	h, ok := metrics.requests[key]
+	if !ok {
+		h = newHistogram(requestBuckets)
+		metrics.requests[key] = h
+	}
+	h.observe(time.Since(start).Seconds())
+	if kind != "" {
+		metrics.errors[requestErrorKey{device, endpoint, kind}]++
+	}
+}
+
+// Error type of a failed HTTP request
+func requestErrorKind(err error) string {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	return "network"
+}
+
+func observeScan(start time.Time, players int) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	metrics.scans.observe(time.Since(start).Seconds())
+	metrics.discovered = players
+}
+
+// Gauges of a player from its latest status; a nil status only changes up
+func observePlayer(player PlayerInfo, status *Status, up bool) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	gauges, ok := metrics.players[player.IP]
+	if !ok {
+		gauges = &playerGauges{}
+		metrics.players[player.IP] = gauges
+	}
+	gauges.player = player
+	gauges.up = up
+	if status != nil {
+		gauges.volume = status.Volume
+		gauges.playing = playState(status) == "playing"
+	}
+}
+
+// Keep the player gauges up to date from the server's watchers and
+// reachability checks. Players that vanish from later scans stay in the
+// list, so they show up as down.
+func startHealthProbe(server *apiServer) {
+	events := server.events.Subscribe()
+	for _, player := range server.knownPlayers() {
+		if status := server.lastStatus(player.IP); status != nil {
+			observePlayer(player, status, true)
+		}
+	}
+
+	go func() {
+		defer server.events.Unsubscribe(events)
+		for event := range events {
+			switch event.Type {
+			case "players":
+				players := server.knownPlayers()
+				metrics.mu.Lock()
+				for ip, gauges := range metrics.players {
+					if _, ok := findPlayer(players, ip); !ok {
+						gauges.up = false
+					}
+				}
+				metrics.mu.Unlock()
+			case "offline":
+				if player, ok := findPlayer(server.knownPlayers(), event.IP); ok {
+					observePlayer(player, nil, false)
+				}
+			default:
+				if player, ok := findPlayer(server.knownPlayers(), event.IP); ok && event.Status != nil {
+					observePlayer(player, event.Status, true)
+				}
+			}
+		}
+	}()
+}
+
+// Serve /metrics and /healthz; fails when the address can't be bound
+func serveMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	log.Printf("serving metrics on %s", addr)
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			log.Printf("metrics server failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w)
+}
+
+type healthResponse struct {
+	Status    string `json:"status"`
+	Players   int    `json:"players"`
+	PlayersUp int    `json:"players_up"`
+}
+
+// The daemon is healthy while it runs; unreachable players are reported
+// but are the business of /metrics alerts
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	metrics.mu.Lock()
+	health := healthResponse{Status: "ok", Players: len(metrics.players)}
+	for _, gauges := range metrics.players {
+		if gauges.up {
+			health.PlayersUp++
+		}
+	}
+	metrics.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, health)
+}
+
+func writeMetrics(w io.Writer) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	var ips []string
+	for ip := range metrics.players {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	writeMetricHeader(w, "bluesoundplayer_player_up", "gauge", "Whether the player answered the last probe.")
+	for _, ip := range ips {
+		gauges := metrics.players[ip]
+		fmt.Fprintf(w, "bluesoundplayer_player_up{%s} %d\n",
+			metricLabels("player", gauges.player.Name, "ip", ip, "type", string(gauges.player.Type)), boolMetric(gauges.up))
+	}
+	writeMetricHeader(w, "bluesoundplayer_player_volume", "gauge", "Volume of the player (0-100).")
+	for _, ip := range ips {
+		gauges := metrics.players[ip]
+		fmt.Fprintf(w, "bluesoundplayer_player_volume{%s} %d\n", metricLabels("player", gauges.player.Name, "ip", ip), gauges.volume)
+	}
+	writeMetricHeader(w, "bluesoundplayer_player_playing", "gauge", "Whether the player is playing.")
+	for _, ip := range ips {
+		gauges := metrics.players[ip]
+		fmt.Fprintf(w, "bluesoundplayer_player_playing{%s} %d\n", metricLabels("player", gauges.player.Name, "ip", ip), boolMetric(gauges.playing))
+	}
+
+	var requests []requestKey
+	for key := range metrics.requests {
+		requests = append(requests, key)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].device != requests[j].device {
+			return requests[i].device < requests[j].device
+		}
+		return requests[i].endpoint < requests[j].endpoint
+	})
+	writeMetricHeader(w, "bluesoundplayer_request_duration_seconds", "histogram", "Duration of player API requests by endpoint or SOAP action.")
+	for _, key := range requests {
+		writeHistogram(w, "bluesoundplayer_request_duration_seconds", metricLabels("device", key.device, "endpoint", key.endpoint), metrics.requests[key])
+	}
+
+	var failures []requestErrorKey
+	for key := range metrics.errors {
+		failures = append(failures, key)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		if a.device != b.device {
+			return a.device < b.device
+		}
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		return a.kind < b.kind
+	})
+	writeMetricHeader(w, "bluesoundplayer_request_errors_total", "counter", "Failed player API requests by error type.")
+	for _, key := range failures {
+		fmt.Fprintf(w, "bluesoundplayer_request_errors_total{%s} %d\n",
+			metricLabels("device", key.device, "endpoint", key.endpoint, "type", key.kind), metrics.errors[key])
+	}
+
+	writeMetricHeader(w, "bluesoundplayer_discovery_scan_duration_seconds", "histogram", "Duration of network scans for players.")
+	writeHistogram(w, "bluesoundplayer_discovery_scan_duration_seconds", "", metrics.scans)
+	writeMetricHeader(w, "bluesoundplayer_discovered_players", "gauge", "Players found by the last network scan.")
+	fmt.Fprintf(w, "bluesoundplayer_discovered_players %d\n", metrics.discovered)
+}
+
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeHistogram(w io.Writer, name, labels string, h *histogram) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	var cumulative uint64
+	for i, bound := range h.buckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%s%sle=\"%g\"} %d\n", name, labels, sep, bound, cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %g\n", name, labels, h.sum)
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.count)
+}
+
+// name="value" pairs with escaped values
+func metricLabels(pairs ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	var labels []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pairs[i], escaper.Replace(pairs[i+1])))
+	}
+	return strings.Join(labels, ",")
+}
+
+func boolMetric(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
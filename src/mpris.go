@@ -0,0 +1,438 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// MPRIS2 media player on the session bus for the player selected in the
+// TUI, so media keys, desktop widgets and playerctl control it
+
+const (
+	mprisBusName     = "org.mpris.MediaPlayer2.bluesoundplayer"
+	mprisPath        = dbusObjectPath("/org/mpris/MediaPlayer2")
+	mprisRootIface   = "org.mpris.MediaPlayer2"
+	mprisPlayerIface = "org.mpris.MediaPlayer2.Player"
+	mprisNoTrack     = dbusObjectPath("/org/mpris/MediaPlayer2/TrackList/NoTrack")
+
+	dbusPropertiesIface     = "org.freedesktop.DBus.Properties"
+	dbusIntrospectIface     = "org.freedesktop.DBus.Introspectable"
+	dbusPeerIface           = "org.freedesktop.DBus.Peer"
+	dbusErrUnknownMethod    = "org.freedesktop.DBus.Error.UnknownMethod"
+	dbusErrUnknownProp      = "org.freedesktop.DBus.Error.UnknownProperty"
+	dbusErrInvalidArgs      = "org.freedesktop.DBus.Error.InvalidArgs"
+	dbusErrFailed           = "org.freedesktop.DBus.Error.Failed"
+	dbusErrPropertyReadOnly = "org.freedesktop.DBus.Error.PropertyReadOnly"
+)
+
+const mprisIntrospection = `<!DOCTYPE node PUBLIC "-//freedesktop//DTD D-BUS Object Introspection 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/introspect.dtd">
+<node>
+  <interface name="org.freedesktop.DBus.Introspectable">
+    <method name="Introspect"><arg name="data" type="s" direction="out"/></method>
+  </interface>
+  <interface name="org.freedesktop.DBus.Peer">
+    <method name="Ping"/>
+  </interface>
+  <interface name="org.freedesktop.DBus.Properties">
+    <method name="Get"><arg name="interface" type="s" direction="in"/><arg name="property" type="s" direction="in"/><arg name="value" type="v" direction="out"/></method>
+    <method name="GetAll"><arg name="interface" type="s" direction="in"/><arg name="properties" type="a{sv}" direction="out"/></method>
+    <method name="Set"><arg name="interface" type="s" direction="in"/><arg name="property" type="s" direction="in"/><arg name="value" type="v" direction="in"/></method>
+    <signal name="PropertiesChanged"><arg name="interface" type="s"/><arg name="changed" type="a{sv}"/><arg name="invalidated" type="as"/></signal>
+  </interface>
+  <interface name="org.mpris.MediaPlayer2">
+    <method name="Raise"/>
+    <method name="Quit"/>
+    <property name="CanQuit" type="b" access="read"/>
+    <property name="CanRaise" type="b" access="read"/>
+    <property name="HasTrackList" type="b" access="read"/>
+    <property name="Identity" type="s" access="read"/>
+    <property name="SupportedUriSchemes" type="as" access="read"/>
+    <property name="SupportedMimeTypes" type="as" access="read"/>
+  </interface>
+  <interface name="org.mpris.MediaPlayer2.Player">
+    <method name="Next"/>
+    <method name="Previous"/>
+    <method name="Pause"/>
+    <method name="PlayPause"/>
+    <method name="Stop"/>
+    <method name="Play"/>
+    <method name="Seek"><arg name="Offset" type="x" direction="in"/></method>
+    <method name="SetPosition"><arg name="TrackId" type="o" direction="in"/><arg name="Position" type="x" direction="in"/></method>
+    <method name="OpenUri"><arg name="Uri" type="s" direction="in"/></method>
+    <signal name="Seeked"><arg name="Position" type="x"/></signal>
+    <property name="PlaybackStatus" type="s" access="read"/>
+    <property name="Rate" type="d" access="readwrite"/>
+    <property name="Metadata" type="a{sv}" access="read"/>
+    <property name="Volume" type="d" access="readwrite"/>
+    <property name="Position" type="x" access="read"/>
+    <property name="MinimumRate" type="d" access="read"/>
+    <property name="MaximumRate" type="d" access="read"/>
+    <property name="CanGoNext" type="b" access="read"/>
+    <property name="CanGoPrevious" type="b" access="read"/>
+    <property name="CanPlay" type="b" access="read"/>
+    <property name="CanPause" type="b" access="read"/>
+    <property name="CanSeek" type="b" access="read"/>
+    <property name="CanControl" type="b" access="read"/>
+  </interface>
+</node>
+`
+
+type mprisService struct {
+	conn *dbusConn
+
+	mu     sync.Mutex
+	client AudioClient
+	player PlayerInfo
+	status *Status
+	stop   chan struct{}
+}
+
+var mpris *mprisService
+
+// Publish the MPRIS player on Linux. Without a session bus (e.g. over SSH)
+// the TUI runs without it.
+func startMPRIS() {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	conn, err := dialSessionBus()
+	if err != nil {
+		return
+	}
+
+	// A second instance gets its own name, like other MPRIS players
+	name := mprisBusName
+	for _, candidate := range []string{mprisBusName, fmt.Sprintf("%s.instance%d", mprisBusName, os.Getpid())} {
+		reply, err := conn.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "RequestName", "su", candidate, uint32(4))
+		if err != nil {
+			conn.Close()
+			return
+		}
+		// 1: primary owner
+		if result, _ := reply.Body[0].(uint32); result == 1 {
+			name = candidate
+			break
+		}
+		name = ""
+	}
+	if name == "" {
+		conn.Close()
+		return
+	}
+
+	mpris = &mprisService{conn: conn}
+	go mpris.serve()
+}
+
+// Follow the TUI's player; no-op if it didn't change
+func mprisSetPlayer(client AudioClient, player PlayerInfo) {
+	if mpris != nil {
+		mpris.setPlayer(client, player)
+	}
+}
+
+func (m *mprisService) setPlayer(client AudioClient, player PlayerInfo) {
+	m.mu.Lock()
+	if client == m.client {
+		m.mu.Unlock()
+		return
+	}
+	if m.stop != nil {
+		close(m.stop)
+	}
+	stop := make(chan struct{})
+	m.client, m.player, m.status, m.stop = client, player, nil, stop
+	m.mu.Unlock()
+
+	m.emitChanged(mprisRootIface, map[string]dbusVariant{"Identity": m.identity()})
+
+	if client == nil {
+		return
+	}
+	onStatus := func(status *Status) {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		m.updateStatus(status)
+	}
+	if source, ok := client.(EventSource); ok {
+		go source.Watch(stop, onStatus, func() {})
+	} else {
+		go pollStatus(client, stop, onStatus)
+	}
+}
+
+// Remember the status and signal what changed
+func (m *mprisService) updateStatus(status *Status) {
+	m.mu.Lock()
+	old := m.status
+	m.status = status
+	player := m.player
+	m.mu.Unlock()
+
+	changed := make(map[string]dbusVariant)
+	if old == nil || playState(old) != playState(status) {
+		changed["PlaybackStatus"] = mprisPlaybackStatus(status)
+	}
+	if old == nil || old.Song != status.Song || old.Artist != status.Artist ||
+		old.Album != status.Album || old.Image != status.Image {
+		changed["Metadata"] = mprisMetadata(player, status)
+	}
+	if old == nil || old.Volume != status.Volume {
+		changed["Volume"] = dbusVariant{"d", float64(status.Volume) / 100}
+	}
+	if len(changed) > 0 {
+		m.emitChanged(mprisPlayerIface, changed)
+	}
+}
+
+func (m *mprisService) emitChanged(iface string, changed map[string]dbusVariant) {
+	m.conn.Emit(mprisPath, dbusPropertiesIface, "PropertiesChanged", "sa{sv}as", iface, changed, []string{})
+}
+
+func (m *mprisService) current() (AudioClient, PlayerInfo, *Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.client, m.player, m.status
+}
+
+func (m *mprisService) identity() dbusVariant {
+	_, player, _ := m.current()
+	if player.Name == "" {
+		return dbusVariant{"s", "Multi-Room Player"}
+	}
+	return dbusVariant{"s", "Multi-Room Player: " + player.Name}
+}
+
+func (m *mprisService) serve() {
+	for call := range m.conn.calls {
+		m.handle(call)
+	}
+}
+
+func (m *mprisService) handle(call *dbusMessage) {
+	if call.Path != mprisPath {
+		m.conn.ReplyError(call, dbusErrUnknownMethod, fmt.Sprintf("no object at %s", call.Path))
+		return
+	}
+
+	switch call.Interface {
+	case dbusIntrospectIface:
+		if call.Member == "Introspect" {
+			m.conn.Reply(call, "s", mprisIntrospection)
+			return
+		}
+	case dbusPeerIface:
+		if call.Member == "Ping" {
+			m.conn.Reply(call, "")
+			return
+		}
+	case dbusPropertiesIface:
+		m.handleProperties(call)
+		return
+	case mprisRootIface, mprisPlayerIface, "":
+		if m.handleMethod(call) {
+			return
+		}
+	}
+	m.conn.ReplyError(call, dbusErrUnknownMethod, fmt.Sprintf("unknown method %s.%s", call.Interface, call.Member))
+}
+
+func (m *mprisService) handleProperties(call *dbusMessage) {
+	iface, _ := argString(call, 0)
+
+	switch call.Member {
+	case "Get":
+		name, _ := argString(call, 1)
+		value, ok := m.properties(iface)[name]
+		if !ok {
+			m.conn.ReplyError(call, dbusErrUnknownProp, fmt.Sprintf("unknown property %s.%s", iface, name))
+			return
+		}
+		m.conn.Reply(call, "v", value)
+
+	case "GetAll":
+		m.conn.Reply(call, "a{sv}", m.properties(iface))
+
+	case "Set":
+		name, _ := argString(call, 1)
+		var value dbusVariant
+		if len(call.Body) > 2 {
+			value, _ = call.Body[2].(dbusVariant)
+		}
+		if iface != mprisPlayerIface || (name != "Volume" && name != "Rate") {
+			m.conn.ReplyError(call, dbusErrPropertyReadOnly, fmt.Sprintf("%s.%s is read-only", iface, name))
+			return
+		}
+		level, ok := value.Value.(float64)
+		if !ok {
+			m.conn.ReplyError(call, dbusErrInvalidArgs, "expected a double")
+			return
+		}
+		// Only the normal rate exists
+		if name == "Rate" {
+			m.conn.Reply(call, "")
+			return
+		}
+		m.control(call, func(client AudioClient) error {
+			cancelFade(client)
+			return client.SetVolume(int(math.Round(math.Max(0, math.Min(1, level)) * 100)))
+		})
+
+	default:
+		m.conn.ReplyError(call, dbusErrUnknownMethod, "unknown method "+call.Member)
+	}
+}
+
+// Root and Player methods; false if the method doesn't exist
+func (m *mprisService) handleMethod(call *dbusMessage) bool {
+	switch call.Member {
+	case "Raise", "Quit", "Seek", "SetPosition":
+		// Not supported by the players, but must exist
+		m.conn.Reply(call, "")
+	case "Play":
+		m.control(call, AudioClient.Play)
+	case "Pause":
+		m.control(call, AudioClient.Pause)
+	case "Stop":
+		m.control(call, AudioClient.Stop)
+	case "Next":
+		m.control(call, AudioClient.Next)
+	case "Previous":
+		m.control(call, AudioClient.Previous)
+	case "PlayPause":
+		m.control(call, func(client AudioClient) error {
+			status, err := client.GetStatus()
+			if err != nil {
+				return err
+			}
+			if playState(status) == "playing" {
+				return client.Pause()
+			}
+			return client.Play()
+		})
+	case "OpenUri":
+		uri, ok := argString(call, 0)
+		if !ok {
+			m.conn.ReplyError(call, dbusErrInvalidArgs, "expected a URI")
+			return true
+		}
+		m.control(call, func(client AudioClient) error {
+			return client.PlayURL(uri, "")
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// Run an action on the player, reply and publish the new status right away.
+// Players can take seconds to answer, so this happens in the background and
+// serve keeps answering other calls meanwhile.
+func (m *mprisService) control(call *dbusMessage, action func(AudioClient) error) {
+	client, _, _ := m.current()
+	if client == nil {
+		m.conn.ReplyError(call, dbusErrFailed, "no player selected")
+		return
+	}
+
+	go func() {
+		if err := action(client); err != nil {
+			m.conn.ReplyError(call, dbusErrFailed, err.Error())
+			return
+		}
+		m.conn.Reply(call, "")
+
+		if status, err := client.GetStatus(); err == nil {
+			if current, _, _ := m.current(); current == client {
+				m.updateStatus(status)
+			}
+		}
+	}()
+}
+
+func (m *mprisService) properties(iface string) map[string]dbusVariant {
+	switch iface {
+	case mprisRootIface:
+		return map[string]dbusVariant{
+			"CanQuit":             {"b", false},
+			"CanRaise":            {"b", false},
+			"HasTrackList":        {"b", false},
+			"Identity":            m.identity(),
+			"SupportedUriSchemes": {"as", []string{"http", "https"}},
+			"SupportedMimeTypes":  {"as", []string{"audio/mpeg", "audio/aac", "audio/flac", "audio/ogg"}},
+		}
+
+	case mprisPlayerIface:
+		client, player, status := m.current()
+		if status == nil && client != nil {
+			status, _ = client.GetStatus()
+		}
+		if status == nil {
+			status = &Status{State: "stop"}
+		}
+		return map[string]dbusVariant{
+			"PlaybackStatus": mprisPlaybackStatus(status),
+			"Rate":           {"d", 1.0},
+			"Metadata":       mprisMetadata(player, status),
+			"Volume":         {"d", float64(status.Volume) / 100},
+			"Position":       {"x", int64(0)},
+			"MinimumRate":    {"d", 1.0},
+			"MaximumRate":    {"d", 1.0},
+			"CanGoNext":      {"b", client != nil},
+			"CanGoPrevious":  {"b", client != nil},
+			"CanPlay":        {"b", client != nil},
+			"CanPause":       {"b", client != nil},
+			"CanSeek":        {"b", false},
+			"CanControl":     {"b", true},
+		}
+	}
+	return map[string]dbusVariant{}
+}
+
+func mprisPlaybackStatus(status *Status) dbusVariant {
+	switch playState(status) {
+	case "playing":
+		return dbusVariant{"s", "Playing"}
+	case "paused":
+		return dbusVariant{"s", "Paused"}
+	}
+	return dbusVariant{"s", "Stopped"}
+}
+
+func mprisMetadata(player PlayerInfo, status *Status) dbusVariant {
+	metadata := map[string]dbusVariant{
+		"mpris:trackid": {"o", mprisNoTrack},
+	}
+	if status.Song != "" {
+		hash := fnv.New32a()
+		fmt.Fprintf(hash, "%s\x00%s\x00%s", status.Song, status.Artist, status.Album)
+		metadata["mpris:trackid"] = dbusVariant{"o", dbusObjectPath(fmt.Sprintf("/org/bluesoundplayer/track/%d", hash.Sum32()))}
+		metadata["xesam:title"] = dbusVariant{"s", status.Song}
+	}
+	if status.Artist != "" {
+		metadata["xesam:artist"] = dbusVariant{"as", []string{status.Artist}}
+	}
+	if status.Album != "" {
+		metadata["xesam:album"] = dbusVariant{"s", status.Album}
+	}
+	if image := playerArtworkURL(player, status.Image); image != "" {
+		metadata["mpris:artUrl"] = dbusVariant{"s", image}
+	}
+	return dbusVariant{"a{sv}", metadata}
+}
+
+func argString(call *dbusMessage, i int) (string, bool) {
+	if i >= len(call.Body) {
+		return "", false
+	}
+	s, ok := call.Body[i].(string)
+	return s, ok
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Minimal MQTT 3.1.1 client: QoS 0 publishing, subscriptions, keepalive and
+// a last will. Enough for the Home Assistant bridge without a dependency.
+
+// Control packet types (upper nibble of the fixed header)
+const (
+	mqttConnect    = 1
+	mqttConnAck    = 2
+	mqttPublish    = 3
+	mqttPubAck     = 4
+	mqttSubscribe  = 8
+	mqttSubAck     = 9
+	mqttPingReq    = 12
+	mqttPingResp   = 13
+	mqttDisconnect = 14
+)
+
+const mqttKeepAlive = 60 * time.Second
+
+type mqttOptions struct {
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+
+	// Published by the broker when the connection is lost
+	WillTopic   string
+	WillPayload string
+	WillRetain  bool
+}
+
+type mqttMessage struct {
+	Topic   string
+	Payload []byte
+}
+
+type mqttClient struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	writeMu  sync.Mutex
+	packetID uint16
+
+	messages chan mqttMessage
+	done     chan struct{}
+	failOnce sync.Once
+	err      error
+}
+
+// Connect to the broker ("host:port", "tcp://" and a missing port are
+// accepted) and wait for its CONNACK
+func dialMQTT(opts mqttOptions) (*mqttClient, error) {
+	addr := strings.TrimPrefix(opts.Broker, "tcp://")
+	addr = strings.TrimPrefix(addr, "mqtt://")
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "1883")
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &mqttClient{
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		messages: make(chan mqttMessage, 32),
+		done:     make(chan struct{}),
+	}
+	if err := c.connect(opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go c.readLoop()
+	go c.keepAlive()
+	return c, nil
+}
+
+func (c *mqttClient) connect(opts mqttOptions) error {
+	var flags byte = 0x02 // clean session
+	var payload []byte
+	payload = appendMQTTString(payload, opts.ClientID)
+	if opts.WillTopic != "" {
+		flags |= 0x04
+		if opts.WillRetain {
+			flags |= 0x20
+		}
+		payload = appendMQTTString(payload, opts.WillTopic)
+		payload = appendMQTTString(payload, opts.WillPayload)
+	}
+	if opts.Username != "" {
+		flags |= 0x80
+		payload = appendMQTTString(payload, opts.Username)
+		if opts.Password != "" {
+			flags |= 0x40
+			payload = appendMQTTString(payload, opts.Password)
+		}
+	}
+
+	var body []byte
+	body = appendMQTTString(body, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(mqttKeepAlive/time.Second))
+	body = append(body, payload...)
+
+	c.conn.SetDeadline(time.Now().Add(10 * time.Second))
+	defer c.conn.SetDeadline(time.Time{})
+
+	if err := c.writePacket(mqttConnect<<4, body); err != nil {
+		return err
+	}
+	header, ack, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+	if header>>4 != mqttConnAck || len(ack) != 2 {
+		return errors.New("mqtt: expected CONNACK")
+	}
+	if ack[1] != 0 {
+		return fmt.Errorf("mqtt: connection refused (code %d)", ack[1])
+	}
+	return nil
+}
+
+// Messages of all subscriptions; closed when the connection ends
+func (c *mqttClient) Messages() <-chan mqttMessage {
+	return c.messages
+}
+
+// Closed when the connection is lost; Err tells why
+func (c *mqttClient) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *mqttClient) Err() error {
+	<-c.done
+	return c.err
+}
+
+func (c *mqttClient) Publish(topic string, payload []byte, retain bool) error {
+	var header byte = mqttPublish << 4
+	if retain {
+		header |= 0x01
+	}
+	body := appendMQTTString(nil, topic)
+	body = append(body, payload...)
+	return c.writePacket(header, body)
+}
+
+// Subscribe with QoS 0; the SUBACK arrives asynchronously
+func (c *mqttClient) Subscribe(filters ...string) error {
+	c.writeMu.Lock()
+	c.packetID++
+	id := c.packetID
+	c.writeMu.Unlock()
+
+	body := binary.BigEndian.AppendUint16(nil, id)
+	for _, filter := range filters {
+		body = appendMQTTString(body, filter)
+		body = append(body, 0)
+	}
+	return c.writePacket(mqttSubscribe<<4|0x02, body)
+}
+
+// Disconnect cleanly; the broker doesn't send the last will
+func (c *mqttClient) Close() error {
+	c.writePacket(mqttDisconnect<<4, nil)
+	return c.conn.Close()
+}
+
+func (c *mqttClient) readLoop() {
+	defer close(c.messages)
+	for {
+		header, body, err := c.readPacket()
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		switch header >> 4 {
+		case mqttPublish:
+			message, id, err := parseMQTTPublish(header, body)
+			if err != nil {
+				c.fail(err)
+				return
+			}
+			if id != 0 {
+				c.writePacket(mqttPubAck<<4, binary.BigEndian.AppendUint16(nil, id))
+			}
+			select {
+			case c.messages <- message:
+			case <-c.done:
+				return
+			}
+		case mqttSubAck, mqttPingResp:
+		default:
+			c.fail(fmt.Errorf("mqtt: unexpected packet type %d", header>>4))
+			return
+		}
+	}
+}
+
+func (c *mqttClient) keepAlive() {
+	ticker := time.NewTicker(mqttKeepAlive / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.writePacket(mqttPingReq<<4, nil); err != nil {
+				c.fail(err)
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *mqttClient) fail(err error) {
+	c.failOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func (c *mqttClient) writePacket(header byte, body []byte) error {
+	packet := []byte{header}
+	packet = appendMQTTLength(packet, len(body))
+	packet = append(packet, body...)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(packet)
+	return err
+}
+
+func (c *mqttClient) readPacket() (byte, []byte, error) {
+	header, err := c.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Remaining length: up to four bytes, 7 bits each
+	length, shift := 0, 0
+	for {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		if shift > 21 {
+			return 0, nil, errors.New("mqtt: malformed packet length")
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// Topic and packet ID (0 for QoS 0) of an incoming PUBLISH
+func parseMQTTPublish(header byte, body []byte) (mqttMessage, uint16, error) {
+	if len(body) < 2 {
+		return mqttMessage{}, 0, errors.New("mqtt: malformed PUBLISH")
+	}
+	topicLen := int(binary.BigEndian.Uint16(body))
+	rest := body[2:]
+	if len(rest) < topicLen {
+		return mqttMessage{}, 0, errors.New("mqtt: malformed PUBLISH")
+	}
+	message := mqttMessage{Topic: string(rest[:topicLen])}
+	rest = rest[topicLen:]
+
+	var id uint16
+	if (header>>1)&0x03 > 0 {
+		if len(rest) < 2 {
+			return mqttMessage{}, 0, errors.New("mqtt: malformed PUBLISH")
+		}
+		id = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+	message.Payload = rest
+	return message, id, nil
+}
+
+func appendMQTTString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func appendMQTTLength(b []byte, length int) []byte {
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if length == 0 {
+			return b
+		}
+	}
+}
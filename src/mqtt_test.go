@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Player double that records the calls made on it
+type fakeClient struct {
+	AudioClient
+
+	mu     sync.Mutex
+	calls  []string
+	status Status
+}
+
+func (f *fakeClient) record(call string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+	return nil
+}
+
+func (f *fakeClient) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeClient) GetStatus() (*Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := f.status
+	return &status, nil
+}
+
+func (f *fakeClient) GetPresets() ([]Preset, error) {
+	return []Preset{{ID: 1, Name: "Radio One"}, {ID: 2, Name: "Jazz"}}, nil
+}
+
+func (f *fakeClient) Play() error     { return f.record("play") }
+func (f *fakeClient) Pause() error    { return f.record("pause") }
+func (f *fakeClient) Stop() error     { return f.record("stop") }
+func (f *fakeClient) Next() error     { return f.record("next") }
+func (f *fakeClient) Previous() error { return f.record("prev") }
+
+// One connection of the in-process broker; it reuses the client's framing
+type testBroker struct {
+	t    *testing.T
+	conn *mqttClient
+}
+
+type brokerPublish struct {
+	payload string
+	retain  bool
+}
+
+func acceptMQTT(t *testing.T, ln net.Listener) *testBroker {
+	t.Helper()
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	return &testBroker{t: t, conn: &mqttClient{conn: conn, reader: bufio.NewReader(conn)}}
+}
+
+func (b *testBroker) read() (byte, []byte) {
+	b.t.Helper()
+	header, body, err := b.conn.readPacket()
+	if err != nil {
+		b.t.Fatalf("broker: %v", err)
+	}
+	return header, body
+}
+
+func (b *testBroker) publish(topic, payload string) {
+	b.t.Helper()
+	if err := b.conn.Publish(topic, []byte(payload), false); err != nil {
+		b.t.Fatalf("broker: %v", err)
+	}
+}
+
+// Read the client's packets until a PUBLISH to topic, collecting every
+// PUBLISH and SUBSCRIBE filter on the way
+func (b *testBroker) readUntil(topic string, published map[string]brokerPublish, filters *[]string) brokerPublish {
+	b.t.Helper()
+	for {
+		header, body := b.read()
+		switch header >> 4 {
+		case mqttPublish:
+			message, _, err := parseMQTTPublish(header, body)
+			if err != nil {
+				b.t.Fatal(err)
+			}
+			publish := brokerPublish{payload: string(message.Payload), retain: header&0x01 != 0}
+			if published != nil {
+				published[message.Topic] = publish
+			}
+			if message.Topic == topic {
+				return publish
+			}
+		case mqttSubscribe:
+			rest := body[2:] // packet ID
+			for len(rest) > 2 {
+				n := int(binary.BigEndian.Uint16(rest))
+				*filters = append(*filters, string(rest[2:2+n]))
+				rest = rest[2+n+1:] // filter and QoS
+			}
+		case mqttPingReq:
+		default:
+			b.t.Fatalf("broker: unexpected packet type %d", header>>4)
+		}
+	}
+}
+
+// Split a CONNECT body into its flags and payload strings
+func parseMQTTConnect(t *testing.T, body []byte) (byte, uint16, []string) {
+	t.Helper()
+	if len(body) < 10 || string(body[2:6]) != "MQTT" || body[6] != 4 {
+		t.Fatalf("CONNECT is not MQTT 3.1.1: %q", body)
+	}
+	flags, keepAlive := body[7], binary.BigEndian.Uint16(body[8:10])
+	var fields []string
+	for rest := body[10:]; len(rest) > 0; {
+		n := int(binary.BigEndian.Uint16(rest))
+		fields = append(fields, string(rest[2:2+n]))
+		rest = rest[2+n:]
+	}
+	return flags, keepAlive, fields
+}
+
+func TestMQTTConnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	type result struct {
+		client *mqttClient
+		err    error
+	}
+	dialed := make(chan result, 1)
+	go func() {
+		client, err := dialMQTT(mqttOptions{
+			Broker:      "tcp://" + ln.Addr().String(),
+			ClientID:    "test",
+			Username:    "user",
+			Password:    "secret",
+			WillTopic:   "bluesoundplayer/status",
+			WillPayload: "offline",
+			WillRetain:  true,
+		})
+		dialed <- result{client, err}
+	}()
+
+	broker := acceptMQTT(t, ln)
+	header, body := broker.read()
+	if header != mqttConnect<<4 {
+		t.Fatalf("first packet = %#x, want CONNECT", header)
+	}
+	flags, keepAlive, fields := parseMQTTConnect(t, body)
+	if flags != 0x02|0x04|0x20|0x40|0x80 {
+		t.Errorf("connect flags = %#x", flags)
+	}
+	if keepAlive != uint16(mqttKeepAlive/time.Second) {
+		t.Errorf("keepalive = %d", keepAlive)
+	}
+	want := []string{"test", "bluesoundplayer/status", "offline", "user", "secret"}
+	if strings.Join(fields, "|") != strings.Join(want, "|") {
+		t.Errorf("connect payload = %q, want %q", fields, want)
+	}
+
+	// The client waits for the CONNACK before it returns
+	select {
+	case r := <-dialed:
+		t.Fatalf("dialMQTT returned before CONNACK: %v", r.err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	broker.conn.writePacket(mqttConnAck<<4, []byte{0, 0})
+	r := <-dialed
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	defer r.client.Close()
+
+	broker.publish("bluesoundplayer/kitchen/set/command", "play")
+	select {
+	case message := <-r.client.Messages():
+		if message.Topic != "bluesoundplayer/kitchen/set/command" || string(message.Payload) != "play" {
+			t.Errorf("message = %s %q", message.Topic, message.Payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message delivered")
+	}
+}
+
+func TestMQTTConnectRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	go func() {
+		broker := acceptMQTT(t, ln)
+		broker.read()
+		broker.conn.writePacket(mqttConnAck<<4, []byte{0, 5}) // not authorized
+	}()
+	if _, err := dialMQTT(mqttOptions{Broker: ln.Addr().String(), ClientID: "test"}); err == nil || !strings.Contains(err.Error(), "code 5") {
+		t.Fatalf("err = %v, want connection refused", err)
+	}
+}
+
+func TestMQTTBridge(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	player := PlayerInfo{IP: "192.0.2.10", Name: "Living Room", Brand: "Bluesound", Model: "NODE", Type: DeviceTypeBluOS}
+	fake := &fakeClient{status: Status{State: "play", Song: "Song", Artist: "Artist", Album: "Album", Volume: 30}}
+	server := newAPIServer()
+	server.players = []PlayerInfo{player}
+	server.clients[player.IP] = fake
+	server.statuses[player.IP] = &Status{State: "play", Song: "Song", Artist: "Artist", Volume: 30}
+
+	bridge := &mqttBridge{server: server, config: MQTTConfig{
+		Broker:          ln.Addr().String(),
+		ClientID:        "bluesoundplayer",
+		TopicPrefix:     "bluesoundplayer",
+		DiscoveryPrefix: "homeassistant",
+	}}
+	go bridge.session()
+
+	broker := acceptMQTT(t, ln)
+	if header, _ := broker.read(); header != mqttConnect<<4 {
+		t.Fatalf("first packet = %#x, want CONNECT", header)
+	}
+	broker.conn.writePacket(mqttConnAck<<4, []byte{0, 0})
+
+	published := make(map[string]brokerPublish)
+	var filters []string
+	state := broker.readUntil("bluesoundplayer/living_room/state", published, &filters)
+
+	if strings.Join(filters, "|") != "bluesoundplayer/+/set/+" {
+		t.Errorf("subscriptions = %q", filters)
+	}
+	for _, topic := range []string{"bluesoundplayer/status", "bluesoundplayer/living_room/availability"} {
+		if p := published[topic]; p.payload != "online" || !p.retain {
+			t.Errorf("%s = %+v, want retained online", topic, p)
+		}
+	}
+
+	var got mqttState
+	if err := json.Unmarshal([]byte(state.payload), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !state.retain || got.Player != "Living Room" || got.State != "playing" || got.Song != "Song" || got.Volume != 30 {
+		t.Errorf("state = %+v (retain %v)", got, state.retain)
+	}
+
+	// Discovery: every entity belongs to the player's device and is
+	// available only while both the bridge and the player are online
+	discovery := map[string]func(config map[string]interface{}) bool{
+		"homeassistant/sensor/bluesoundplayer_living_room/state/config": func(c map[string]interface{}) bool {
+			return c["state_topic"] == "bluesoundplayer/living_room/state"
+		},
+		"homeassistant/number/bluesoundplayer_living_room/volume/config": func(c map[string]interface{}) bool {
+			return c["command_topic"] == "bluesoundplayer/living_room/set/volume"
+		},
+		"homeassistant/button/bluesoundplayer_living_room/pause/config": func(c map[string]interface{}) bool {
+			return c["command_topic"] == "bluesoundplayer/living_room/set/command" && c["payload_press"] == "pause"
+		},
+		"homeassistant/select/bluesoundplayer_living_room/preset/config": func(c map[string]interface{}) bool {
+			options, _ := c["options"].([]interface{})
+			return len(options) == 2 && options[0] == "Radio One"
+		},
+	}
+	for topic, check := range discovery {
+		p, ok := published[topic]
+		if !ok {
+			t.Errorf("no discovery config on %s", topic)
+			continue
+		}
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(p.payload), &config); err != nil {
+			t.Fatalf("%s: %v", topic, err)
+		}
+		device, _ := config["device"].(map[string]interface{})
+		availability, _ := config["availability"].([]interface{})
+		if !p.retain || device["name"] != "Living Room" || len(availability) != 2 || config["availability_mode"] != "all" || !check(config) {
+			t.Errorf("%s = %s (retain %v)", topic, p.payload, p.retain)
+		}
+	}
+
+	// Only transport commands are accepted on the command topic; the state
+	// published after the valid one shows that the others were dropped
+	for _, payload := range []string{"reboot", "volume 10", "", "play;stop", "standby"} {
+		broker.publish("bluesoundplayer/living_room/set/command", payload)
+	}
+	broker.publish("bluesoundplayer/unknown/set/command", "stop")
+	broker.publish("bluesoundplayer/living_room/set/command", "Pause")
+	broker.readUntil("bluesoundplayer/living_room/state", nil, &filters)
+
+	if calls := fake.recorded(); strings.Join(calls, ",") != "pause" {
+		t.Errorf("player calls = %q, want only pause", calls)
+	}
+}
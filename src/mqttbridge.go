@@ -0,0 +1,405 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MQTT bridge of the serve mode, configured in mqtt.json:
+//
+//	{"broker": "192.168.1.5:1883", "username": "...", "password": "..."}
+//
+// Topics (prefix "bluesoundplayer" by default, player IDs are slugs of the
+// player names, e.g. "living_room"):
+//
+//	bluesoundplayer/status                   "online"/"offline" (last will)
+//	bluesoundplayer/<player>/availability    "online"/"offline"
+//	bluesoundplayer/<player>/state           JSON state, retained
+//	bluesoundplayer/<player>/set/volume      "30"
+//	bluesoundplayer/<player>/set/preset      preset ID or name
+//	bluesoundplayer/<player>/set/command     play, pause, stop, next or prev
+//
+// Home Assistant discovery configs are published below
+// homeassistant/<component>/bluesoundplayer_<player>/.
+type MQTTConfig struct {
+	Broker           string `json:"broker"`
+	Username         string `json:"username,omitempty"`
+	Password         string `json:"password,omitempty"`
+	ClientID         string `json:"client_id,omitempty"`
+	TopicPrefix      string `json:"topic_prefix,omitempty"`
+	DiscoveryPrefix  string `json:"discovery_prefix,omitempty"`
+	DisableDiscovery bool   `json:"disable_discovery,omitempty"`
+}
+
+const (
+	mqttFile           = "mqtt.json"
+	mqttReconnectDelay = 10 * time.Second
+)
+
+// State published for each player
+type mqttState struct {
+	Player string `json:"player"`
+	IP     string `json:"ip"`
+	State  string `json:"state"` // playing, paused or stopped
+	Song   string `json:"song"`
+	Artist string `json:"artist"`
+	Album  string `json:"album"`
+	Volume int    `json:"volume"`
+	Image  string `json:"image,omitempty"`
+}
+
+type mqttBridge struct {
+	server    *apiServer
+	config    MQTTConfig
+	client    *mqttClient
+	announced map[string]PlayerInfo
+}
+
+func loadMQTTConfig() (MQTTConfig, error) {
+	var config MQTTConfig
+	path, err := configPath(mqttFile)
+	if err != nil {
+		return config, err
+	}
+	if err := loadJSONFile(path, &config); err != nil {
+		return config, err
+	}
+
+	if config.ClientID == "" {
+		config.ClientID = "bluesoundplayer"
+	}
+	if config.TopicPrefix == "" {
+		config.TopicPrefix = "bluesoundplayer"
+	}
+	if config.DiscoveryPrefix == "" {
+		config.DiscoveryPrefix = "homeassistant"
+	}
+	return config, nil
+}
+
+// Keep a connection to the broker, reconnecting when it drops
+func runMQTTBridge(server *apiServer, config MQTTConfig) {
+	bridge := &mqttBridge{server: server, config: config}
+	for {
+		err := bridge.session()
+		log.Printf("mqtt: %v; reconnecting in %s", err, mqttReconnectDelay)
+		time.Sleep(mqttReconnectDelay)
+	}
+}
+
+func (b *mqttBridge) session() error {
+	client, err := dialMQTT(mqttOptions{
+		Broker:      b.config.Broker,
+		ClientID:    b.config.ClientID,
+		Username:    b.config.Username,
+		Password:    b.config.Password,
+		WillTopic:   b.topic("status"),
+		WillPayload: "offline",
+		WillRetain:  true,
+	})
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	b.client = client
+	log.Printf("mqtt: connected to %s", b.config.Broker)
+
+	events := b.server.events.Subscribe()
+	defer b.server.events.Unsubscribe(events)
+
+	if err := client.Subscribe(b.topic("+/set/+")); err != nil {
+		return err
+	}
+	if err := client.Publish(b.topic("status"), []byte("online"), true); err != nil {
+		return err
+	}
+
+	b.announced = make(map[string]PlayerInfo)
+	b.syncPlayers()
+	for _, player := range b.server.knownPlayers() {
+		if status := b.server.lastStatus(player.IP); status != nil {
+			b.publishState(player, status)
+		}
+	}
+
+	for {
+		select {
+		case event := <-events:
+			if event.Type == "players" {
+				b.syncPlayers()
+			} else if event.Type == "offline" || event.Type == "online" {
+				if player, ok := b.announced[event.IP]; ok {
+					b.client.Publish(b.playerTopic(player, "availability"), []byte(event.Type), true)
+				}
+			} else if event.Status != nil {
+				if player, ok := findPlayer(b.server.knownPlayers(), event.IP); ok {
+					b.publishState(player, event.Status)
+				}
+			}
+		case message, ok := <-client.Messages():
+			if !ok {
+				return client.Err()
+			}
+			b.handleCommand(message)
+		case <-client.Done():
+			return client.Err()
+		}
+	}
+}
+
+func (b *mqttBridge) topic(suffix string) string {
+	return b.config.TopicPrefix + "/" + suffix
+}
+
+func (b *mqttBridge) playerTopic(player PlayerInfo, suffix string) string {
+	return b.topic(mqttPlayerID(player) + "/" + suffix)
+}
+
+func (b *mqttBridge) publishJSON(topic string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("mqtt: %v", err)
+		return
+	}
+	// Write errors end the session through client.Done
+	b.client.Publish(topic, data, true)
+}
+
+// Announce new players and mark vanished ones offline
+func (b *mqttBridge) syncPlayers() {
+	players := b.server.knownPlayers()
+	for _, player := range players {
+		if _, ok := b.announced[player.IP]; !ok {
+			b.announce(player)
+			b.announced[player.IP] = player
+		}
+		b.client.Publish(b.playerTopic(player, "availability"), []byte("online"), true)
+	}
+	for ip, player := range b.announced {
+		if _, ok := findPlayer(players, ip); !ok {
+			b.client.Publish(b.playerTopic(player, "availability"), []byte("offline"), true)
+			delete(b.announced, ip)
+		}
+	}
+}
+
+func (b *mqttBridge) publishState(player PlayerInfo, status *Status) {
+	b.publishJSON(b.playerTopic(player, "state"), mqttState{
+		Player: player.Name,
+		IP:     player.IP,
+		State:  playState(status),
+		Song:   status.Song,
+		Artist: status.Artist,
+		Album:  status.Album,
+		Volume: status.Volume,
+		Image:  playerArtworkURL(player, status.Image),
+	})
+}
+
+// Home Assistant has no MQTT media player, so each player becomes a device
+// with sensors, a volume slider, transport buttons and a preset select
+func (b *mqttBridge) announce(player PlayerInfo) {
+	if b.config.DisableDiscovery {
+		return
+	}
+
+	id := mqttPlayerID(player)
+	device := map[string]interface{}{
+		"identifiers":  []string{b.config.TopicPrefix + "_" + id},
+		"name":         player.Name,
+		"manufacturer": player.Brand,
+		"model":        player.Model,
+	}
+	availability := []map[string]string{
+		{"topic": b.topic("status")},
+		{"topic": b.playerTopic(player, "availability")},
+	}
+	stateTopic := b.playerTopic(player, "state")
+
+	entity := func(component, object, name string, options map[string]interface{}) {
+		config := map[string]interface{}{
+			"name":              name,
+			"unique_id":         fmt.Sprintf("%s_%s_%s", b.config.TopicPrefix, id, object),
+			"device":            device,
+			"availability":      availability,
+			"availability_mode": "all",
+		}
+		for key, value := range options {
+			config[key] = value
+		}
+		b.publishJSON(fmt.Sprintf("%s/%s/%s_%s/%s/config", b.config.DiscoveryPrefix, component, b.config.TopicPrefix, id, object), config)
+	}
+
+	entity("sensor", "state", "State", map[string]interface{}{
+		"state_topic":    stateTopic,
+		"value_template": "{{ value_json.state }}",
+		"icon":           "mdi:speaker",
+	})
+	entity("sensor", "now_playing", "Now playing", map[string]interface{}{
+		"state_topic":           stateTopic,
+		"value_template":        "{{ value_json.song }}",
+		"json_attributes_topic": stateTopic,
+		"icon":                  "mdi:music",
+	})
+	entity("number", "volume", "Volume", map[string]interface{}{
+		"state_topic":         stateTopic,
+		"value_template":      "{{ value_json.volume }}",
+		"command_topic":       b.playerTopic(player, "set/volume"),
+		"min":                 0,
+		"max":                 100,
+		"step":                1,
+		"unit_of_measurement": "%",
+		"icon":                "mdi:volume-high",
+	})
+
+	buttons := []struct{ action, name, icon string }{
+		{"play", "Play", "mdi:play"},
+		{"pause", "Pause", "mdi:pause"},
+		{"stop", "Stop", "mdi:stop"},
+		{"next", "Next track", "mdi:skip-next"},
+		{"prev", "Previous track", "mdi:skip-previous"},
+	}
+	for _, button := range buttons {
+		entity("button", button.action, button.name, map[string]interface{}{
+			"command_topic": b.playerTopic(player, "set/command"),
+			"payload_press": button.action,
+			"icon":          button.icon,
+		})
+	}
+
+	client, err := b.server.clientFor(player)
+	if err != nil {
+		return
+	}
+	b.server.controlMu.Lock()
+	presets, err := client.GetPresets()
+	b.server.controlMu.Unlock()
+	if err != nil || len(presets) == 0 {
+		return
+	}
+	var names []string
+	for _, preset := range presets {
+		names = append(names, preset.Name)
+	}
+	entity("select", "preset", "Preset", map[string]interface{}{
+		"command_topic": b.playerTopic(player, "set/preset"),
+		"options":       names,
+		"icon":          "mdi:playlist-music",
+	})
+}
+
+// <prefix>/<player>/set/<volume|preset|command>
+func (b *mqttBridge) handleCommand(message mqttMessage) {
+	parts := strings.Split(strings.TrimPrefix(message.Topic, b.config.TopicPrefix+"/"), "/")
+	if len(parts) != 3 || parts[1] != "set" {
+		return
+	}
+	payload := strings.TrimSpace(string(message.Payload))
+
+	player, ok := b.findPlayer(parts[0])
+	if !ok {
+		log.Printf("mqtt: %v: %q", errPlayerNotFound, parts[0])
+		return
+	}
+	client, err := b.server.clientFor(player)
+	if err != nil {
+		log.Printf("mqtt: %s: %v", player.Name, err)
+		return
+	}
+
+	b.server.controlMu.Lock()
+	err = b.runCommand(client, parts[2], payload)
+	b.server.controlMu.Unlock()
+	if err != nil {
+		log.Printf("mqtt: %s %s %q: %v", player.Name, parts[2], payload, err)
+		return
+	}
+
+	// Don't wait for the player's next event
+	if status, err := client.GetStatus(); err == nil {
+		b.publishState(player, status)
+	}
+}
+
+func (b *mqttBridge) runCommand(client AudioClient, kind, payload string) error {
+	switch kind {
+	case "volume":
+		// Home Assistant sends numbers as "30.0"
+		level, err := strconv.ParseFloat(payload, 64)
+		if err != nil || level < 0 || level > 100 {
+			return errors.New("volume must be between 0 and 100")
+		}
+		cancelFade(client)
+		return client.SetVolume(int(math.Round(level)))
+
+	case "preset":
+		if id, err := strconv.Atoi(payload); err == nil {
+			return client.PlayPreset(id)
+		}
+		presets, err := client.GetPresets()
+		if err != nil {
+			return err
+		}
+		for _, preset := range presets {
+			if strings.EqualFold(preset.Name, payload) {
+				return client.PlayPreset(preset.ID)
+			}
+		}
+		return fmt.Errorf("preset %q not found", payload)
+
+	case "command":
+		switch strings.ToLower(payload) {
+		case "play":
+			return client.Play()
+		case "pause":
+			return client.Pause()
+		case "stop":
+			return client.Stop()
+		case "next":
+			return client.Next()
+		case "prev", "previous":
+			return client.Previous()
+		}
+		return fmt.Errorf("unknown command %q", payload)
+	}
+	return fmt.Errorf("unknown command topic %q", kind)
+}
+
+func (b *mqttBridge) findPlayer(id string) (PlayerInfo, bool) {
+	for _, player := range b.server.knownPlayers() {
+		if mqttPlayerID(player) == id {
+			return player, true
+		}
+	}
+	return PlayerInfo{}, false
+}
+
+// Topic-safe ID from the player name, e.g. "Living Room" → "living_room"
+func mqttPlayerID(player PlayerInfo) string {
+	var id strings.Builder
+	for _, r := range strings.ToLower(player.Name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			id.WriteRune(r)
+		} else if id.Len() > 0 && !strings.HasSuffix(id.String(), "_") {
+			id.WriteByte('_')
+		}
+	}
+	if slug := strings.TrimSuffix(id.String(), "_"); slug != "" {
+		return slug
+	}
+	return strings.ReplaceAll(player.IP, ".", "_")
+}
+
+// BluOS reports artwork relative to the player
+func playerArtworkURL(player PlayerInfo, image string) string {
+	if strings.HasPrefix(image, "/") {
+		return fmt.Sprintf("http://%s:%s%s", player.IP, BluesoundPort, image)
+	}
+	return image
+}
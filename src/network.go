@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
 	"sync"
@@ -25,9 +26,13 @@ type NetworkInterface struct {
 	Subnet string
 }
 
+// Where scan progress is printed; daemon mode discards it
+var scanOutput io.Writer = os.Stdout
+
 // Enhanced network scanner that scans all available interfaces
 func scanForPlayers() ([]PlayerInfo, error) {
-	fmt.Println(getText("scanning"))
+	start := time.Now()
+	fmt.Fprintln(scanOutput, getText("scanning"))
 
 	// Get all network interfaces
 	interfaces, err := getAllNetworkInterfaces()
@@ -39,7 +44,7 @@ func scanForPlayers() ([]PlayerInfo, error) {
 		return nil, fmt.Errorf("no network interfaces found")
 	}
 
-	fmt.Printf(getText("scanning_interfaces")+"\n", len(interfaces))
+	fmt.Fprintf(scanOutput, getText("scanning_interfaces")+"\n", len(interfaces))
 
 	var players []PlayerInfo
 	var mu sync.Mutex
@@ -47,7 +52,7 @@ func scanForPlayers() ([]PlayerInfo, error) {
 
 	// Scan each network interface
 	for _, iface := range interfaces {
-		fmt.Printf(getText("scanning_interface")+"\n", iface.Name, iface.Subnet)
+		fmt.Fprintf(scanOutput, getText("scanning_interface")+"\n", iface.Name, iface.Subnet)
 
 		// Scan all IPs in this subnet in parallel
 		for i := 1; i < 255; i++ {
@@ -68,7 +73,7 @@ func scanForPlayers() ([]PlayerInfo, error) {
 					}
 					if !exists {
 						players = append(players, player)
-						fmt.Printf(getText("found_player")+"\n", player.Name, player.Model, player.IP)
+						fmt.Fprintf(scanOutput, getText("found_player")+"\n", player.Name, player.Model, player.IP)
 					}
 					mu.Unlock()
 				}
@@ -86,7 +91,7 @@ func scanForPlayers() ([]PlayerInfo, error) {
 					}
 					if !exists {
 						players = append(players, player)
-						fmt.Printf(getText("found_player")+"\n", player.Name, player.Model, player.IP)
+						fmt.Fprintf(scanOutput, getText("found_player")+"\n", player.Name, player.Model, player.IP)
 					}
 					mu.Unlock()
 				}
@@ -95,7 +100,8 @@ func scanForPlayers() ([]PlayerInfo, error) {
 	}
 
 	wg.Wait()
-	fmt.Printf(getText("completed_scan")+"\n", len(interfaces))
+	fmt.Fprintf(scanOutput, getText("completed_scan")+"\n", len(interfaces))
+	observeScan(start, len(players))
 	return players, nil
 }
 
@@ -307,3 +313,16 @@ func checkForSonosPlayer(ip string) (PlayerInfo, bool) {
 		Type:  DeviceTypeSonos,
 	}, true
 }
+
+// Check that a known player still answers
+func playerOnline(player PlayerInfo) bool {
+	switch player.Type {
+	case DeviceTypeBluOS:
+		_, ok := checkForBluOSPlayer(player.IP)
+		return ok
+	case DeviceTypeSonos:
+		_, ok := checkForSonosPlayer(player.IP)
+		return ok
+	}
+	return false
+}
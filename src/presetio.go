@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Preset backup formats, chosen by file extension:
+// .json, .xml (BluOS /Presets), .m3u/.m3u8, .pls and .xspf
+
+type presetExport struct {
+	Presets []Preset `json:"presets"`
+}
+
+type XSPFPlaylist struct {
+	XMLName xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version string      `xml:"version,attr"`
+	Tracks  []XSPFTrack `xml:"trackList>track"`
+}
+
+type XSPFTrack struct {
+	Location string `xml:"location"`
+	Title    string `xml:"title,omitempty"`
+	Image    string `xml:"image,omitempty"`
+}
+
+func exportPresets(path string, presets []Preset) error {
+	var data []byte
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err = json.MarshalIndent(presetExport{Presets: presets}, "", "  ")
+
+	case ".xml":
+		data, err = xml.MarshalIndent(Presets{Presets: presets}, "", "  ")
+		data = append([]byte(xml.Header), data...)
+
+	case ".m3u", ".m3u8":
+		var sb strings.Builder
+		sb.WriteString("#EXTM3U\n")
+		for _, preset := range presets {
+			sb.WriteString(fmt.Sprintf("#EXTINF:-1,%s\n%s\n", preset.Name, preset.URL))
+		}
+		data = []byte(sb.String())
+
+	case ".pls":
+		var sb strings.Builder
+		sb.WriteString("[playlist]\n")
+		for i, preset := range presets {
+			sb.WriteString(fmt.Sprintf("File%d=%s\nTitle%d=%s\nLength%d=-1\n", i+1, preset.URL, i+1, preset.Name, i+1))
+		}
+		sb.WriteString(fmt.Sprintf("NumberOfEntries=%d\nVersion=2\n", len(presets)))
+		data = []byte(sb.String())
+
+	case ".xspf":
+		playlist := XSPFPlaylist{Version: "1"}
+		for _, preset := range presets {
+			playlist.Tracks = append(playlist.Tracks, XSPFTrack{Location: preset.URL, Title: preset.Name, Image: preset.Image})
+		}
+		data, err = xml.MarshalIndent(playlist, "", "  ")
+		data = append([]byte(xml.Header), data...)
+
+	default:
+		return fmt.Errorf("unsupported file format %q (use .json, .xml, .m3u, .pls or .xspf)", filepath.Ext(path))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to encode presets: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readPresetFile(path string) ([]Preset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var presets []Preset
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var export presetExport
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		presets = export.Presets
+
+	case ".xml":
+		var parsed Presets
+		if err := xml.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse presets XML: %w", err)
+		}
+		presets = parsed.Presets
+
+	case ".m3u", ".m3u8":
+		presets = parseM3U(string(data))
+
+	case ".pls":
+		presets = parsePLS(string(data))
+
+	case ".xspf":
+		var playlist XSPFPlaylist
+		if err := xml.Unmarshal(data, &playlist); err != nil {
+			return nil, fmt.Errorf("failed to parse XSPF: %w", err)
+		}
+		for _, track := range playlist.Tracks {
+			presets = append(presets, Preset{Name: track.Title, URL: strings.TrimSpace(track.Location), Image: track.Image})
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported file format %q (use .json, .xml, .m3u, .pls or .xspf)", filepath.Ext(path))
+	}
+
+	// Playlists don't always carry names
+	for i := range presets {
+		if presets[i].Name == "" {
+			presets[i].Name = streamTitle(presets[i].URL)
+		}
+	}
+
+	return presets, nil
+}
+
+// Outcome of reconciling imported presets with the existing ones
+type presetImportReport struct {
+	Added     int
+	Unchanged int
+	Conflicts []string
+	Failed    []string
+}
+
+// Reconcile imported presets by URL and name. Existing presets always win;
+// entries that match one but not the other are reported as conflicts.
+func reconcilePresets(existing, imported []Preset, save func(Preset) error) presetImportReport {
+	var report presetImportReport
+	used := make(map[int]bool)
+	for _, preset := range existing {
+		used[preset.ID] = true
+	}
+
+	for _, preset := range imported {
+		// An exact match anywhere wins over partial matches before it
+		conflict := ""
+		unchanged := false
+		for _, current := range existing {
+			sameURL := current.URL == preset.URL
+			sameName := normalizeName(current.Name) == normalizeName(preset.Name)
+			switch {
+			case sameURL && sameName:
+				unchanged = true
+			case conflict != "":
+			case sameURL:
+				conflict = fmt.Sprintf("%s (URL already saved as %q)", preset.Name, current.Name)
+			case sameName:
+				conflict = fmt.Sprintf("%s (name used by preset %d with another URL)", preset.Name, current.ID)
+			}
+			if unchanged {
+				break
+			}
+		}
+
+		switch {
+		case unchanged:
+			report.Unchanged++
+		case conflict != "":
+			report.Conflicts = append(report.Conflicts, conflict)
+		default:
+			// Keep the original slot when it's free
+			if used[preset.ID] {
+				preset.ID = 0
+			}
+			if err := save(preset); err != nil {
+				report.Failed = append(report.Failed, fmt.Sprintf("%s (%v)", preset.Name, err))
+				continue
+			}
+			if preset.ID != 0 {
+				used[preset.ID] = true
+			}
+			report.Added++
+			existing = append(existing, preset)
+		}
+	}
+
+	return report
+}
+
+// Handle "presets [export <file> | import <file>]"
+func handlePresetsCommand(args []string) {
+	if len(args) == 0 {
+		if refresher, ok := tuiState.client.(PresetRefresher); ok {
+			refresher.RefreshPresets()
+		}
+		updatePresets()
+		tuiState.lastAction = "Presets/Favorites refreshed"
+		return
+	}
+
+	if len(args) < 2 {
+		reportUsage(getText("presets_transfer_usage"))
+		return
+	}
+	path := strings.Join(args[1:], " ")
+
+	switch strings.ToLower(args[0]) {
+	case "export":
+		updatePresets()
+		var presets []Preset
+		for _, preset := range tuiState.presets {
+			if !strings.HasPrefix(preset.Name, "[INFO]") {
+				presets = append(presets, preset)
+			}
+		}
+		if err := exportPresets(path, presets); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_exporting_presets"), err))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("presets_exported"), len(presets), path)
+
+	case "import":
+		imported, err := readPresetFile(path)
+		if err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_importing_presets"), err))
+			return
+		}
+
+		var existing []Preset
+		var save func(Preset) error
+		if libraryActive() {
+			existing = tuiState.library.Presets()
+			save = func(preset Preset) error {
+				tuiState.library.Add(LibraryEntry{Name: preset.Name, URL: preset.URL, Image: preset.Image})
+				return nil
+			}
+		} else {
+			manager, ok := currentPresetManager()
+			if !ok {
+				return
+			}
+			if existing, err = tuiState.client.GetPresets(); err != nil {
+				reportError(fmt.Sprintf("%s: %v", getText("error_importing_presets"), err))
+				return
+			}
+			save = manager.SavePreset
+		}
+
+		report := reconcilePresets(existing, imported, save)
+		if libraryActive() {
+			if err := tuiState.library.Save(); err != nil {
+				reportError(fmt.Sprintf("%s: %v", getText("error_library"), err))
+				return
+			}
+		}
+
+		tuiState.lastAction = fmt.Sprintf(getText("presets_imported"), report.Added, report.Unchanged, len(report.Conflicts), len(report.Failed))
+		if len(report.Conflicts) > 0 {
+			tuiState.lastAction += "\n" + getText("presets_conflicts") + "\n  " + strings.Join(report.Conflicts, "\n  ")
+		}
+		if len(report.Failed) > 0 {
+			tuiState.lastAction += "\n" + getText("presets_failed") + "\n  " + strings.Join(report.Failed, "\n  ")
+			tuiState.lastOutcome = outcomeFailed
+		}
+		updatePresets()
+
+	default:
+		reportUsage(getText("presets_transfer_usage"))
+	}
+}
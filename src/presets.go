@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func currentPresetManager() (PresetManager, bool) {
+	manager, ok := tuiState.client.(PresetManager)
+	if !ok {
+		reportError(getText("preset_edit_not_supported"))
+	}
+	return manager, ok
+}
+
+func parsePresetID(arg string) (int, bool) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 1 {
+		reportUsage(getText("invalid_preset_id"))
+		return 0, false
+	}
+	return id, true
+}
+
+// Handle "preset save|rename|delete|move ..."
+func handlePresetCommand(args []string) {
+	if len(args) == 0 {
+		reportUsage(getText("preset_usage"))
+		return
+	}
+
+	manager, ok := currentPresetManager()
+	if !ok {
+		return
+	}
+
+	var err error
+	switch strings.ToLower(args[0]) {
+	case "save":
+		// preset save [slot] [name...]
+		slot := 0
+		nameArgs := args[1:]
+		if len(nameArgs) > 0 {
+			if id, convErr := strconv.Atoi(nameArgs[0]); convErr == nil {
+				slot = id
+				nameArgs = nameArgs[1:]
+			}
+		}
+
+		var current *Preset
+		if current, err = manager.CurrentStream(); err == nil {
+			current.ID = slot
+			if len(nameArgs) > 0 {
+				current.Name = strings.Join(nameArgs, " ")
+			}
+			if err = manager.SavePreset(*current); err == nil {
+				tuiState.lastAction = fmt.Sprintf(getText("preset_saved"), current.Name)
+			}
+		}
+
+	case "rename":
+		if len(args) < 3 {
+			reportUsage(getText("preset_usage"))
+			return
+		}
+		id, ok := parsePresetID(args[1])
+		if !ok {
+			return
+		}
+		name := strings.Join(args[2:], " ")
+		if err = manager.RenamePreset(id, name); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("preset_renamed"), id, name)
+		}
+
+	case "delete", "rm":
+		if len(args) < 2 {
+			reportUsage(getText("preset_usage"))
+			return
+		}
+		id, ok := parsePresetID(args[1])
+		if !ok {
+			return
+		}
+		if err = manager.DeletePreset(id); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("preset_deleted"), id)
+		}
+
+	case "move", "mv":
+		if len(args) < 3 {
+			reportUsage(getText("preset_usage"))
+			return
+		}
+		from, ok1 := parsePresetID(args[1])
+		to, ok2 := parsePresetID(args[2])
+		if !ok1 || !ok2 {
+			return
+		}
+		if err = manager.MovePreset(from, to); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("preset_moved"), from, to)
+		}
+
+	default:
+		reportUsage(getText("preset_usage"))
+		return
+	}
+
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_editing_preset"), err))
+	}
+	updatePresets()
+}
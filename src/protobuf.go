@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Minimal protocol buffers encoding for the gRPC API: varints and
+// length-delimited fields, enough for the messages of player.proto. Zero
+// values are omitted like proto3 does.
+
+// Wire types
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+type protoWriter struct {
+	buf []byte
+}
+
+func (w *protoWriter) tag(field, wireType int) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(field)<<3|uint64(wireType))
+}
+
+// int32/int64 fields; negative numbers take ten bytes like in protobuf
+func (w *protoWriter) int(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	w.tag(field, protoVarint)
+	w.buf = binary.AppendUvarint(w.buf, uint64(v))
+}
+
+// proto3 optional field, written even when zero
+func (w *protoWriter) optionalInt(field int, v *int) {
+	if v == nil {
+		return
+	}
+	w.tag(field, protoVarint)
+	w.buf = binary.AppendUvarint(w.buf, uint64(int64(*v)))
+}
+
+func (w *protoWriter) bool(field int, b bool) {
+	if b {
+		w.int(field, 1)
+	}
+}
+
+func (w *protoWriter) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	w.bytes(field, []byte(s))
+}
+
+func (w *protoWriter) strings(field int, list []string) {
+	for _, s := range list {
+		w.bytes(field, []byte(s))
+	}
+}
+
+// Embedded message; repeated messages are written once per element
+func (w *protoWriter) message(field int, m []byte) {
+	w.bytes(field, m)
+}
+
+func (w *protoWriter) bytes(field int, b []byte) {
+	w.tag(field, protoBytes)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+type protoField struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+// Fields of a decoded message in wire order
+type protoFields []protoField
+
+var errProtoMalformed = errors.New("malformed protobuf message")
+
+func parseProto(data []byte) (protoFields, error) {
+	var fields protoFields
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errProtoMalformed
+		}
+		data = data[n:]
+		field := protoField{num: int(key >> 3)}
+
+		switch key & 7 {
+		case protoVarint:
+			field.varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return nil, errProtoMalformed
+			}
+			data = data[n:]
+		case protoBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return nil, errProtoMalformed
+			}
+			field.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case protoFixed64:
+			if len(data) < 8 {
+				return nil, errProtoMalformed
+			}
+			field.varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case protoFixed32:
+			if len(data) < 4 {
+				return nil, errProtoMalformed
+			}
+			field.varint = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		default:
+			return nil, errProtoMalformed
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Last value wins for singular fields, like in protobuf
+func (f protoFields) last(num int) (protoField, bool) {
+	for i := len(f) - 1; i >= 0; i-- {
+		if f[i].num == num {
+			return f[i], true
+		}
+	}
+	return protoField{}, false
+}
+
+func (f protoFields) string(num int) string {
+	field, _ := f.last(num)
+	return string(field.bytes)
+}
+
+func (f protoFields) int(num int) int {
+	field, _ := f.last(num)
+	return int(int32(field.varint))
+}
+
+func (f protoFields) bool(num int) bool {
+	field, _ := f.last(num)
+	return field.varint != 0
+}
+
+func (f protoFields) strings(num int) []string {
+	var list []string
+	for _, field := range f {
+		if field.num == num {
+			list = append(list, string(field.bytes))
+		}
+	}
+	return list
+}
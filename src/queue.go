@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Number of queue entries shown in the TUI panel
+const queuePanelSize = 10
+
+func updateQueue() {
+	queue, err := tuiState.client.GetQueue()
+	if err != nil {
+		tuiState.queueError = getText("error_loading_queue")
+		tuiState.queue = nil
+	} else {
+		tuiState.queue = queue
+		tuiState.queueError = ""
+	}
+}
+
+// Render the queue panel below the presets section
+func renderQueue() {
+	fmt.Println(getText("queue_title"))
+	if tuiState.queueError != "" {
+		fmt.Println(tuiState.queueError)
+	} else if len(tuiState.queue) == 0 {
+		fmt.Printf("  %s\n", getText("queue_empty"))
+	} else {
+		for i, item := range tuiState.queue {
+			if i >= queuePanelSize {
+				fmt.Printf(getText("queue_more")+"\n", len(tuiState.queue)-queuePanelSize)
+				break
+			}
+			fmt.Printf("  %2d. %s", item.Index, item.Title)
+			if item.Artist != "" {
+				fmt.Printf(" - %s", item.Artist)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+}
+
+func parseQueueIndex(arg string) (int, bool) {
+	index, err := strconv.Atoi(arg)
+	if err != nil || index < 1 {
+		return 0, false
+	}
+	return index, true
+}
+
+// Handle "queue ..." commands
+func handleQueueCommand(args []string) {
+	if len(args) == 0 {
+		updateQueue()
+		tuiState.lastAction = getText("queue_refreshed")
+		return
+	}
+
+	var err error
+	subcommand := strings.ToLower(args[0])
+
+	switch subcommand {
+	case "add", "next":
+		if len(args) < 2 {
+			reportUsage(getText("url_missing"))
+			return
+		}
+		title := strings.Join(args[2:], " ")
+		if err = tuiState.client.AddToQueue(args[1], title, subcommand == "next"); err == nil {
+			tuiState.lastAction = getText("queue_added")
+		}
+
+	case "rm", "remove":
+		index, ok := 0, len(args) > 1
+		if ok {
+			index, ok = parseQueueIndex(args[1])
+		}
+		if !ok {
+			reportUsage(getText("invalid_queue_index"))
+			return
+		}
+		if err = tuiState.client.RemoveFromQueue(index); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("queue_removed"), index)
+		}
+
+	case "mv", "move":
+		if len(args) < 3 {
+			reportUsage(getText("invalid_queue_index"))
+			return
+		}
+		from, ok1 := parseQueueIndex(args[1])
+		to, ok2 := parseQueueIndex(args[2])
+		if !ok1 || !ok2 {
+			reportUsage(getText("invalid_queue_index"))
+			return
+		}
+		if err = tuiState.client.MoveQueueItem(from, to); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("queue_moved"), from, to)
+		}
+
+	case "clear":
+		if err = tuiState.client.ClearQueue(); err == nil {
+			tuiState.lastAction = getText("queue_cleared")
+		}
+
+	case "play", "jump":
+		index, ok := 0, len(args) > 1
+		if ok {
+			index, ok = parseQueueIndex(args[1])
+		}
+		if !ok {
+			reportUsage(getText("invalid_queue_index"))
+			return
+		}
+		if err = tuiState.client.PlayQueueItem(index); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("queue_playing"), index)
+			updateStatus()
+		}
+
+	case "save":
+		if len(args) < 2 {
+			reportUsage(getText("queue_usage"))
+			return
+		}
+		name := strings.Join(args[1:], " ")
+		if err = tuiState.client.SaveQueue(name); err == nil {
+			tuiState.lastAction = fmt.Sprintf(getText("queue_saved"), name)
+		}
+
+	default:
+		reportUsage(getText("queue_usage"))
+		return
+	}
+
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_queue"), err))
+	}
+	updateQueue()
+}
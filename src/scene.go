@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scenes set up several players at once, read from scenes.json:
+//
+//	{
+//	  "scenes": [
+//	    {
+//	      "name": "Dinner",
+//	      "group": ["Kitchen", "Living Room"],
+//	      "players": [
+//	        {"player": "Kitchen", "preset": 3, "volume": 25},
+//	        {"player": "Living Room", "volume": 15}
+//	      ]
+//	    }
+//	  ]
+//	}
+type SceneConfig struct {
+	Scenes []Scene `json:"scenes"`
+}
+
+// Group lists BluOS players to group, master first
+type Scene struct {
+	Name    string        `json:"name"`
+	Group   []string      `json:"group,omitempty"`
+	Players []ScenePlayer `json:"players"`
+}
+
+type ScenePlayer struct {
+	Player string `json:"player"`
+	Preset int    `json:"preset,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Volume *int   `json:"volume,omitempty"`
+	Stop   bool   `json:"stop,omitempty"`
+}
+
+const scenesFile = "scenes.json"
+
+func loadScenes() ([]Scene, error) {
+	path, err := configPath(scenesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config SceneConfig
+	if err := loadJSONFile(path, &config); err != nil {
+		return nil, err
+	}
+	return config.Scenes, nil
+}
+
+func findScene(scenes []Scene, name string) (Scene, bool) {
+	for _, scene := range scenes {
+		if strings.EqualFold(scene.Name, name) {
+			return scene, true
+		}
+	}
+	return Scene{}, false
+}
+
+// Apply every step of the scene; failures of single players don't stop the
+// others and are reported together
+func applyScene(scene Scene, players []PlayerInfo, clientFor func(PlayerInfo) (AudioClient, error)) error {
+	var failures []string
+	fail := func(player string, err error) {
+		failures = append(failures, fmt.Sprintf("%s: %v", player, err))
+	}
+
+	if len(scene.Group) > 1 {
+		master, ok := findPlayer(players, scene.Group[0])
+		if !ok {
+			fail(scene.Group[0], errPlayerNotFound)
+		} else if client, err := clientFor(master); err != nil {
+			fail(master.Name, err)
+		} else {
+			for _, name := range scene.Group[1:] {
+				slave, ok := findPlayer(players, name)
+				if !ok {
+					fail(name, errPlayerNotFound)
+					continue
+				}
+				if err := client.AddSlave(slave.IP); err != nil {
+					fail(slave.Name, err)
+				}
+			}
+		}
+	}
+
+	for _, step := range scene.Players {
+		player, ok := findPlayer(players, step.Player)
+		if !ok {
+			fail(step.Player, errPlayerNotFound)
+			continue
+		}
+		client, err := clientFor(player)
+		if err != nil {
+			fail(player.Name, err)
+			continue
+		}
+
+		if step.Volume != nil {
+			if err := client.SetVolume(*step.Volume); err != nil {
+				fail(player.Name, err)
+			}
+		}
+
+		switch {
+		case step.Stop:
+			err = client.Stop()
+		case step.URL != "":
+			err = client.PlayURL(step.URL, step.Title)
+		case step.Preset > 0:
+			err = client.PlayPreset(step.Preset)
+		}
+		if err != nil {
+			fail(player.Name, err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("scene %q partly failed: %s", scene.Name, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Handle "scene [name]"
+func handleSceneCommand(args []string) {
+	scenes, err := loadScenes()
+	if err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_scene"), err))
+		return
+	}
+
+	if len(args) == 0 {
+		if len(scenes) == 0 {
+			tuiState.lastAction = getText("no_scenes")
+			return
+		}
+		var names []string
+		for _, scene := range scenes {
+			names = append(names, scene.Name)
+		}
+		tuiState.lastAction = getText("scenes_title") + " " + strings.Join(names, ", ")
+		return
+	}
+
+	scene, ok := findScene(scenes, strings.Join(args, " "))
+	if !ok {
+		reportError(fmt.Sprintf(getText("scene_not_found"), strings.Join(args, " ")))
+		return
+	}
+	if err := applyScene(scene, tuiState.availablePlayers, newClientForPlayer); err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_scene"), err))
+		return
+	}
+	tuiState.lastAction = fmt.Sprintf(getText("scene_applied"), scene.Name)
+	updateStatus()
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scheduled commands for daemon mode, read from schedule.json:
+//
+//	{
+//	  "log": "/var/log/bluesoundplayer.log",
+//	  "jobs": [
+//	    {"cron": "30 6 * * 1-5", "player": "Kitchen", "command": "play 3"},
+//	    {"at": "22:00", "days": "daily", "player": "192.168.1.20", "command": "stop --fade 30s"}
+//	  ]
+//	}
+type ScheduleConfig struct {
+	Log  string        `json:"log,omitempty"`
+	Jobs []ScheduleJob `json:"jobs"`
+}
+
+// A job runs on a cron expression or at a fixed time on some weekdays
+type ScheduleJob struct {
+	Name    string `json:"name,omitempty"`
+	Cron    string `json:"cron,omitempty"`
+	At      string `json:"at,omitempty"`
+	Days    string `json:"days,omitempty"`
+	Player  string `json:"player"`
+	Command string `json:"command"`
+}
+
+const scheduleFile = "schedule.json"
+
+func loadSchedule() (*ScheduleConfig, error) {
+	path, err := configPath(scheduleFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ScheduleConfig{}
+	if err := loadJSONFile(path, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (j ScheduleJob) label() string {
+	if j.Name != "" {
+		return j.Name
+	}
+	return j.Command
+}
+
+// Whether the job is due in the minute of t
+func (j ScheduleJob) due(t time.Time) (bool, error) {
+	if j.Cron != "" {
+		expr, err := parseCron(j.Cron)
+		if err != nil {
+			return false, err
+		}
+		return expr.matches(t), nil
+	}
+
+	at, ok := parseAlarmTime(j.At)
+	if !ok {
+		return false, fmt.Errorf("job needs a cron expression or an \"at\" time (HH:MM)")
+	}
+	days := everyDay
+	if j.Days != "" {
+		var err error
+		if days, err = parseWeekdays(j.Days); err != nil {
+			return false, err
+		}
+	}
+	return at == t.Format("15:04") && (days == 0 || days.Has(t.Weekday())), nil
+}
+
+// Standard five-field cron expression: minute hour day-of-month month
+// day-of-week, with *, lists, ranges and steps (*/15, 1-5, 0,30)
+type cronExpr struct {
+	minute, hour, dom, month, dow []bool
+	domAny, dowAny                bool
+}
+
+func parseCron(spec string) (*cronExpr, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q needs 5 fields", spec)
+	}
+
+	var expr cronExpr
+	var err error
+	if expr.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if expr.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if expr.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if expr.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if expr.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// 7 is Sunday as well
+	expr.dow[0] = expr.dow[0] || expr.dow[7]
+	// As in cron, fields starting with * (also */2) don't restrict the day
+	expr.domAny = strings.HasPrefix(fields[2], "*")
+	expr.dowAny = strings.HasPrefix(fields[4], "*")
+	return &expr, nil
+}
+
+func parseCronField(field string, min, max int) ([]bool, error) {
+	values := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx != -1 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			step = n
+			part = part[:idx]
+		}
+
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			n, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid cron field %q", field)
+			}
+			from, to = n, n
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid cron field %q", field)
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("cron field %q out of range %d-%d", field, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+func (c *cronExpr) matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+
+	// As in cron, if both day-of-month and day-of-week are restricted,
+	// either is enough; otherwise both must match
+	domMatch := c.dom[t.Day()]
+	dowMatch := c.dow[int(t.Weekday())]
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Long-running mode without TUI: rings local alarms, runs scheduled jobs,
+// records the play history and scrobbles.
+// With metricsAddr set, /metrics and /healthz are served on it; with
+// grpcAddr the gRPC API
+func runDaemon(metricsAddr, grpcAddr string) error {
+	interactive = false
+	scanOutput = io.Discard
+
+	config, err := loadSchedule()
+	if err != nil {
+		return err
+	}
+	if config.Log != "" {
+		logFile, err := os.OpenFile(config.Log, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("could not open log file: %w", err)
+		}
+		defer logFile.Close()
+		log.SetOutput(logFile)
+	}
+
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	listenBrainz, err := loadListenBrainzConfig()
+	if err != nil {
+		return err
+	}
+
+	players, err := scanForPlayers()
+	if err != nil {
+		return err
+	}
+	log.Printf("daemon started: %d players, %d jobs", len(players), len(config.Jobs))
+
+	tuiState.library, _ = loadLibrary()
+	tuiState.availablePlayers = players
+	startAlarmScheduler()
+
+	// Players are watched for the play history, hooks, metrics and the gRPC API
+	api := newAPIServer()
+	api.setPlayers(players)
+	if metricsAddr != "" {
+		startHealthProbe(api)
+		if err := serveMetrics(metricsAddr); err != nil {
+			return err
+		}
+	}
+	go api.rescanPeriodically()
+	api.runTask(func() { runHistory(api) })
+	if listenBrainz.Token != "" {
+		api.runTask(func() { runScrobbler(api, listenBrainz) })
+	}
+	if hooks.configured() {
+		go runHooks(api, hooks)
+	}
+	if grpcAddr != "" {
+		go func() {
+			if err := serveGRPC(grpcAddr, api); err != nil {
+				log.Printf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+	api.exitOnSignal()
+
+	for {
+		// Wake up at the start of every minute
+		now := time.Now()
+		time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		now = time.Now()
+
+		// Re-read the schedule so edits apply without a restart
+		if updated, err := loadSchedule(); err != nil {
+			log.Printf("could not reload schedule: %v", err)
+		} else {
+			config = updated
+		}
+
+		for _, job := range config.Jobs {
+			due, err := job.due(now)
+			if err != nil {
+				log.Printf("job %q: %v", job.label(), err)
+				continue
+			}
+			if due {
+				runScheduledJob(api, job)
+			}
+		}
+	}
+}
+
+// Run a job like an API command, so it doesn't get in the way of gRPC calls
+// running at the same time
+func runScheduledJob(api *apiServer, job ScheduleJob) {
+	player, ok := findPlayer(api.knownPlayers(), job.Player)
+	if !ok {
+		// The player may have joined the network after the last scan
+		if err := api.rescan(); err == nil {
+			player, ok = findPlayer(api.knownPlayers(), job.Player)
+		}
+	}
+	if !ok {
+		log.Printf("job %q skipped: player %q not found", job.label(), job.Player)
+		return
+	}
+	if !playerOnline(player) {
+		log.Printf("job %q skipped: player %s (%s) is offline", job.label(), player.Name, player.IP)
+		return
+	}
+
+	client, err := api.clientFor(player)
+	if err != nil {
+		log.Printf("job %q skipped: %v", job.label(), err)
+		return
+	}
+
+	api.controlMu.Lock()
+	result, _ := api.runCommand(player, client, job.Command)
+	api.controlMu.Unlock()
+	log.Printf("job %q on %s: %s", job.label(), player.Name, strings.ReplaceAll(result, "\n", " | "))
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scrobbling of the serve and daemon modes to ListenBrainz or a compatible
+// server, configured in listenbrainz.json:
+//
+//	{"token": "...", "url": "https://api.listenbrainz.org", "players": ["Kitchen"]}
+//
+// A track counts as listened after half its length or four minutes,
+// whichever comes first. Listens wait in scrobble_queue.json until the
+// server has accepted them, so they survive network outages and restarts.
+type ListenBrainzConfig struct {
+	Token   string   `json:"token"`
+	URL     string   `json:"url,omitempty"`
+	Players []string `json:"players,omitempty"` // all players if empty
+}
+
+const (
+	listenBrainzFile       = "listenbrainz.json"
+	scrobbleQueueFile      = "scrobble_queue.json"
+	defaultListenBrainzURL = "https://api.listenbrainz.org"
+
+	scrobbleMinListen     = 4 * time.Minute
+	scrobbleRetryInterval = time.Minute
+	scrobbleBatchSize     = 100 // listens per request
+)
+
+type Listen struct {
+	ListenedAt    int64          `json:"listened_at"`
+	TrackMetadata ListenMetadata `json:"track_metadata"`
+}
+
+type ListenMetadata struct {
+	ArtistName     string     `json:"artist_name"`
+	TrackName      string     `json:"track_name"`
+	ReleaseName    string     `json:"release_name,omitempty"`
+	AdditionalInfo ListenInfo `json:"additional_info"`
+}
+
+type ListenInfo struct {
+	SubmissionClient string `json:"submission_client"`
+	MusicServiceName string `json:"music_service_name,omitempty"`
+	DurationMs       int    `json:"duration_ms,omitempty"`
+}
+
+type listenSubmission struct {
+	ListenType string   `json:"listen_type"` // "single" or "import"
+	Payload    []Listen `json:"payload"`
+}
+
+// Error of a submission the server will never accept
+type listenRejectedError struct {
+	status int
+	body   string
+}
+
+func (e *listenRejectedError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.status, e.body)
+}
+
+type scrobbler struct {
+	config  ListenBrainzConfig
+	client  *http.Client
+	pending chan struct{} // listens were queued
+
+	mu    sync.Mutex // guards queue and its file
+	queue []Listen
+}
+
+func loadListenBrainzConfig() (ListenBrainzConfig, error) {
+	var config ListenBrainzConfig
+	path, err := configPath(listenBrainzFile)
+	if err != nil {
+		return config, err
+	}
+	if err := loadJSONFile(path, &config); err != nil {
+		return config, err
+	}
+
+	if config.URL == "" {
+		config.URL = defaultListenBrainzURL
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return config, nil
+}
+
+// Submit the listens of the server's players until the server stops. Ended
+// tracks are only queued here and submitted in the background, so a slow
+// server doesn't hold up watching the players.
+func runScrobbler(server *apiServer, config ListenBrainzConfig) {
+	s := &scrobbler{
+		config:  config,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		pending: make(chan struct{}, 1),
+	}
+	if err := s.loadQueue(); err != nil {
+		log.Printf("scrobble: could not read queue: %v", err)
+	}
+	go s.submitQueued()
+
+	watchTracks(server, func(track playedTrack) {
+		if s.wanted(track) && scrobbleEligible(track) {
+			s.enqueue(newListen(track))
+		}
+	})
+	// Stopping: the queue is saved and sent on the next start
+}
+
+func (s *scrobbler) enqueue(listen Listen) {
+	s.mu.Lock()
+	s.queue = append(s.queue, listen)
+	s.saveQueue()
+	s.mu.Unlock()
+
+	select {
+	case s.pending <- struct{}{}:
+	default:
+	}
+}
+
+// Submit the queue when listens are added, retrying every minute
+func (s *scrobbler) submitQueued() {
+	retry := time.NewTicker(scrobbleRetryInterval)
+	defer retry.Stop()
+
+	for {
+		s.flush()
+		select {
+		case <-s.pending:
+		case <-retry.C:
+		}
+	}
+}
+
+func (s *scrobbler) wanted(track playedTrack) bool {
+	if len(s.config.Players) == 0 {
+		return true
+	}
+	for _, name := range s.config.Players {
+		if name == track.IP || strings.EqualFold(name, track.Player) {
+			return true
+		}
+	}
+	return false
+}
+
+// Half the track or four minutes. The player's position only counts for
+// tracks with a length; on streams it is the time since the stream started.
+func scrobbleEligible(track playedTrack) bool {
+	if track.Artist == "" || track.Song == "" {
+		return false
+	}
+
+	listened := time.Duration(track.Listened) * time.Second
+	needed := scrobbleMinListen
+	if track.Duration > 0 {
+		listened = max(listened, time.Duration(track.Position)*time.Second)
+		needed = min(needed, time.Duration(track.Duration)*time.Second/2)
+	}
+	return listened >= needed
+}
+
+func newListen(track playedTrack) Listen {
+	return Listen{
+		ListenedAt: track.Time.Unix(),
+		TrackMetadata: ListenMetadata{
+			ArtistName:  track.Artist,
+			TrackName:   track.Song,
+			ReleaseName: track.Album,
+			AdditionalInfo: ListenInfo{
+				SubmissionClient: "bluesoundplayer",
+				MusicServiceName: track.Source,
+				DurationMs:       track.Duration * 1000,
+			},
+		},
+	}
+}
+
+func (s *scrobbler) loadQueue() error {
+	path, err := configPath(scrobbleQueueFile)
+	if err != nil {
+		return err
+	}
+	return loadJSONFile(path, &s.queue)
+}
+
+// Write the queue to its file (mu held)
+func (s *scrobbler) saveQueue() {
+	path, err := configPath(scrobbleQueueFile)
+	if err == nil {
+		err = saveJSONFile(path, s.queue)
+	}
+	if err != nil {
+		log.Printf("scrobble: could not save queue: %v", err)
+	}
+}
+
+// Submit the queued listens in batches; on errors the rest waits for the
+// next retry. Listens the server rejects are dropped, they would block the
+// queue forever.
+func (s *scrobbler) flush() {
+	for {
+		s.mu.Lock()
+		batch := slices.Clone(s.queue[:min(len(s.queue), scrobbleBatchSize)])
+		queued := len(s.queue)
+		s.mu.Unlock()
+		if len(batch) == 0 {
+			return
+		}
+
+		done, err := len(batch), s.submit(batch)
+		var rejected *listenRejectedError
+		if errors.As(err, &rejected) && len(batch) == 1 {
+			log.Printf("scrobble: dropped listen of %q: %v", batch[0].TrackMetadata.TrackName, rejected)
+			err = nil
+		} else if errors.As(err, &rejected) {
+			// One bad listen rejects the whole batch: find it and keep the others
+			done, err = s.submitEach(batch)
+		} else if err != nil {
+			done = 0
+		}
+
+		// Listens queued meanwhile were appended behind the batch
+		if done > 0 {
+			s.mu.Lock()
+			s.queue = s.queue[done:]
+			s.saveQueue()
+			s.mu.Unlock()
+		}
+
+		if err != nil {
+			log.Printf("scrobble: %v; %d listens queued", err, queued-done)
+			return
+		}
+	}
+}
+
+// Submit the listens one by one, dropping those the server rejects; returns
+// how many were submitted or dropped before any other error
+func (s *scrobbler) submitEach(listens []Listen) (int, error) {
+	for i, listen := range listens {
+		err := s.submit([]Listen{listen})
+		var rejected *listenRejectedError
+		if errors.As(err, &rejected) {
+			log.Printf("scrobble: dropped listen of %q: %v", listen.TrackMetadata.TrackName, rejected)
+		} else if err != nil {
+			return i, err
+		}
+	}
+	return len(listens), nil
+}
+
+func (s *scrobbler) submit(listens []Listen) error {
+	submission := listenSubmission{ListenType: "single", Payload: listens}
+	if len(listens) > 1 {
+		submission.ListenType = "import"
+	}
+	data, err := json.Marshal(submission)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.URL+"/1/submit-listens", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+s.config.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	// Bad tokens, rate limits and server errors are worth retrying
+	if resp.StatusCode == http.StatusBadRequest {
+		return &listenRejectedError{resp.StatusCode, strings.TrimSpace(string(body))}
+	}
+	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
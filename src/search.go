@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Search presets and, where supported, the player's services and library.
+// Results are shown as a browse level so bplay/bqueue work on them.
+func runSearch(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		reportUsage(getText("search_missing"))
+		return
+	}
+
+	var results []BrowseItem
+	needle := normalizeName(query)
+	for _, preset := range tuiState.presets {
+		if strings.Contains(normalizeName(preset.Name), needle) {
+			results = append(results, BrowseItem{
+				Title:    preset.Name,
+				Image:    preset.Image,
+				PlayURI:  preset.URL,
+				Source:   getText("search_source_presets"),
+				PresetID: preset.ID,
+			})
+		}
+	}
+
+	if searcher, ok := tuiState.client.(Searcher); ok {
+		found, err := searcher.Search(query)
+		if err != nil && len(results) == 0 {
+			reportError(fmt.Sprintf("%s: %v", getText("error_searching"), err))
+			return
+		}
+		for _, item := range found {
+			if !containsPlayURI(results, item.PlayURI) {
+				results = append(results, item)
+			}
+		}
+	}
+
+	tuiState.browseStack = []*BrowseResult{{
+		Title: fmt.Sprintf("🔍 %s", query),
+		Items: results,
+	}}
+	tuiState.lastAction = fmt.Sprintf(getText("search_results"), len(results), query)
+}
+
+// Sonos favorites show up both as presets and as search hits
+func containsPlayURI(items []BrowseItem, uri string) bool {
+	if uri == "" {
+		return false
+	}
+	for _, item := range items {
+		if item.PlayURI == uri {
+			return true
+		}
+	}
+	return false
+}
+
+func playPresetItem(item *BrowseItem, queue bool) {
+	if queue {
+		if err := tuiState.client.AddToQueue(item.PlayURI, item.Title, false); err != nil {
+			reportError(fmt.Sprintf("%s: %v", getText("error_queue"), err))
+			return
+		}
+		tuiState.lastAction = fmt.Sprintf(getText("browse_queued"), item.Title)
+		updateQueue()
+		return
+	}
+
+	if err := playPresetByID(item.PresetID); err != nil {
+		reportError(fmt.Sprintf("%s: %v", getText("error_playing_preset"), err))
+		return
+	}
+	tuiState.lastAction = fmt.Sprintf(getText("browse_playing"), item.Title)
+	updateStatus()
+}
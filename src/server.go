@@ -0,0 +1,720 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// JSON HTTP API for dashboards and scripts. Players are discovered once and
+// their clients are kept, so requests don't re-scan the network.
+//
+//	GET  /api/players                      all players
+//	POST /api/players/rescan               discover players again
+//	GET  /api/players/{player}/status      status of a player (name or IP)
+//	GET  /api/players/{player}/presets     presets/favorites
+//	POST /api/players/{player}/play        resume, or {"preset": 3} / {"url": "...", "title": "..."}
+//	POST /api/players/{player}/pause|stop|next|prev
+//	POST /api/players/{player}/volume      {"level": 30} or {"delta": -5}
+//	POST /api/players/{player}/group       {"slaves": ["Kitchen"]}
+//	POST /api/players/{player}/ungroup
+//	POST /api/players/{player}/command     {"command": "sleep 30m"} (see apiCommands)
+//	GET  /api/scenes                       configured scenes
+//	POST /api/scenes/{name}                apply a scene
+//	GET  /api/events                       WebSocket with status/track/volume/topology/offline/online events
+//	GET  /api/i18n?lang=de                 texts for the web UI
+//	GET  /                                 web UI
+//
+// POST requests must be sent as application/json. Browsers can't send that
+// cross-site without asking first, so other web pages can't control the
+// players through a visitor's browser.
+
+// How often players are rediscovered in the background
+const rescanInterval = 10 * time.Minute
+
+// How often watched players are checked, and how many failed checks in a row
+// make a player offline
+const (
+	playerCheckInterval = 30 * time.Second
+	playerCheckFailures = 2
+)
+
+// How long background tasks get to save their state when the process stops
+const shutdownTimeout = 10 * time.Second
+
+// TUI commands the command endpoint runs. Commands that read or write files
+// of the user's choice (presets export/import) or change the TUI are left out.
+var apiCommands = []string{
+	"play", "playurl", "pause", "stop", "next", "prev", "previous", "vol", "volume",
+	"status", "sleep", "fade", "alarm", "alarms", "scene", "scenes", "history",
+	"stats", "queue", "group", "ungroup", "lib", "library",
+}
+
+type apiServer struct {
+	mu       sync.Mutex
+	players  []PlayerInfo
+	clients  map[string]AudioClient
+	watchers map[string]chan struct{}
+	statuses map[string]*Status
+	events   *EventBus
+
+	// Player requests run one at a time: clients cache state (e.g. Sonos
+	// favorites) and TUI commands share the global tuiState
+	controlMu sync.Mutex
+
+	// Closed on SIGINT/SIGTERM; the tasks then save their state and return
+	stopping chan struct{}
+	tasks    sync.WaitGroup
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+type playRequest struct {
+	Preset int    `json:"preset"`
+	URL    string `json:"url"`
+	Title  string `json:"title"`
+}
+
+type volumeRequest struct {
+	Level *int `json:"level"`
+	Delta int  `json:"delta"`
+}
+
+type groupRequest struct {
+	Slaves []string `json:"slaves"`
+}
+
+type commandRequest struct {
+	Command string `json:"command"`
+}
+
+type commandResponse struct {
+	Result string `json:"result"`
+}
+
+func newAPIServer() *apiServer {
+	return &apiServer{
+		clients:  make(map[string]AudioClient),
+		watchers: make(map[string]chan struct{}),
+		statuses: make(map[string]*Status),
+		events:   NewEventBus(),
+		stopping: make(chan struct{}),
+	}
+}
+
+func runServer(addr string) error {
+	interactive = false
+	scanOutput = io.Discard
+
+	// Sonos players deliver their events to this server; on a loopback
+	// address they can't reach it, so they are polled instead
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		warnUnauthenticated("HTTP API", addr)
+		genaEvents = newGENAListener(port)
+	}
+
+	server := newAPIServer()
+	if err := server.rescan(); err != nil {
+		return err
+	}
+	go server.rescanPeriodically()
+
+	tuiState.library, _ = loadLibrary()
+	startAlarmScheduler()
+
+	mqttConfig, err := loadMQTTConfig()
+	if err != nil {
+		return err
+	}
+	if mqttConfig.Broker != "" {
+		go runMQTTBridge(server, mqttConfig)
+	}
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	if hooks.configured() {
+		go runHooks(server, hooks)
+	}
+	server.runTask(func() { runHistory(server) })
+
+	listenBrainz, err := loadListenBrainzConfig()
+	if err != nil {
+		return err
+	}
+	if listenBrainz.Token != "" {
+		server.runTask(func() { runScrobbler(server, listenBrainz) })
+	}
+	server.exitOnSignal()
+
+	mux := http.NewServeMux()
+	server.routes(mux)
+
+	log.Printf("serving API on %s (%d players)", addr, len(server.players))
+	return http.ListenAndServe(addr, mux)
+}
+
+// Run a background task that must be done before the process exits
+func (s *apiServer) runTask(task func()) {
+	s.tasks.Add(1)
+	go func() {
+		defer s.tasks.Done()
+		task()
+	}()
+}
+
+// On SIGINT or SIGTERM let the tasks finish what they have open (e.g. the
+// tracks still playing), then exit
+func (s *apiServer) exitOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		log.Printf("%v received, stopping", <-signals)
+		close(s.stopping)
+
+		done := make(chan struct{})
+		go func() {
+			s.tasks.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(shutdownTimeout):
+			log.Printf("stopping without waiting any longer for background tasks")
+		}
+		os.Exit(0)
+	}()
+}
+
+func (s *apiServer) routes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/players", requireJSON(s.handlePlayers))
+	mux.HandleFunc("/api/players/", requireJSON(s.handlePlayer))
+	mux.HandleFunc("/api/scenes", requireJSON(s.handleScenes))
+	mux.HandleFunc("/api/scenes/", requireJSON(s.handleScenes))
+	mux.HandleFunc("/api/events", s.handleEvents)
+	mux.HandleFunc("/api/i18n", handleI18n)
+	mux.Handle("/", webUIHandler())
+	if genaEvents != nil {
+		mux.Handle(genaPath, genaEvents)
+	}
+}
+
+func (s *apiServer) rescan() error {
+	players, err := scanForPlayers()
+	if err != nil {
+		return err
+	}
+	s.setPlayers(players)
+	return nil
+}
+
+// Rediscover the players every rescanInterval
+func (s *apiServer) rescanPeriodically() {
+	for range time.Tick(rescanInterval) {
+		if err := s.rescan(); err != nil {
+			log.Printf("rescan failed: %v", err)
+		}
+	}
+}
+
+// Take over a player list, watching new players and dropping vanished ones
+func (s *apiServer) setPlayers(players []PlayerInfo) {
+	s.mu.Lock()
+	changed := len(players) != len(s.players)
+	for _, player := range players {
+		if _, ok := findPlayer(s.players, player.IP); !ok {
+			changed = true
+		}
+	}
+	s.players = players
+
+	// Keep the clients and watchers of players that are still around
+	for ip := range s.clients {
+		if _, ok := findPlayer(players, ip); !ok {
+			delete(s.clients, ip)
+			delete(s.statuses, ip)
+		}
+	}
+	for ip, stop := range s.watchers {
+		if _, ok := findPlayer(players, ip); !ok {
+			close(stop)
+			delete(s.watchers, ip)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, player := range players {
+		s.watch(player)
+	}
+	if changed {
+		s.events.Publish(PlayerEvent{Type: "players"})
+	}
+}
+
+// Start pushing the player's changes to the event bus
+func (s *apiServer) watch(player PlayerInfo) {
+	client, err := s.clientFor(player)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	if _, ok := s.watchers[player.IP]; ok {
+		s.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	s.watchers[player.IP] = stop
+	s.mu.Unlock()
+
+	onStatus := func(status *Status) {
+		s.mu.Lock()
+		old := s.statuses[player.IP]
+		s.statuses[player.IP] = status
+		s.mu.Unlock()
+
+		for _, change := range statusChanges(old, status) {
+			s.events.Publish(PlayerEvent{Type: change, Player: player.Name, IP: player.IP, Status: status})
+		}
+	}
+	onTopology := func() {
+		s.events.Publish(PlayerEvent{Type: "topology", Player: player.Name, IP: player.IP})
+	}
+
+	if source, ok := client.(EventSource); ok {
+		go source.Watch(stop, onStatus, onTopology)
+	} else {
+		go pollStatus(client, stop, onStatus)
+	}
+	go s.checkReachable(player, client, stop)
+}
+
+// Publish "offline" once the player stops answering and "online" when it
+// answers again. Watchers quietly retry, so they can't tell, and rescans
+// only notice every rescanInterval.
+func (s *apiServer) checkReachable(player PlayerInfo, client AudioClient, stop <-chan struct{}) {
+	ticker := time.NewTicker(playerCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+
+		status, err := client.GetStatus()
+		if err != nil {
+			failures++
+			if failures == playerCheckFailures {
+				log.Printf("%s (%s) is offline: %v", player.Name, player.IP, err)
+				s.events.Publish(PlayerEvent{Type: "offline", Player: player.Name, IP: player.IP})
+			}
+			continue
+		}
+		if failures >= playerCheckFailures {
+			log.Printf("%s (%s) is back online", player.Name, player.IP)
+			s.events.Publish(PlayerEvent{Type: "online", Player: player.Name, IP: player.IP, Status: status})
+		}
+		failures = 0
+	}
+}
+
+// Interval of WebSocket pings that keep idle connections open
+const wsPingInterval = 30 * time.Second
+
+// /api/events: current statuses first, then every change as it happens
+func (s *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	events := s.events.Subscribe()
+	defer s.events.Unsubscribe(events)
+
+	for _, event := range s.snapshot() {
+		if err := writeEvent(conn, event); err != nil {
+			return
+		}
+	}
+
+	// Clients only send control frames; reading detects when they leave
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			if err := writeEvent(conn, event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.writeFrame(wsOpPing, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// Status events with the last known status of every player
+func (s *apiServer) snapshot() []PlayerEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []PlayerEvent
+	for _, player := range s.players {
+		if status, ok := s.statuses[player.IP]; ok {
+			events = append(events, PlayerEvent{Type: "status", Player: player.Name, IP: player.IP, Status: status, Time: time.Now()})
+		}
+	}
+	return events
+}
+
+func writeEvent(conn *wsConn, event PlayerEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return conn.WriteText(data)
+}
+
+func (s *apiServer) knownPlayers() []PlayerInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PlayerInfo(nil), s.players...)
+}
+
+// Last status reported by the player's watcher, nil if none yet
+func (s *apiServer) lastStatus(ip string) *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statuses[ip]
+}
+
+func (s *apiServer) clientFor(player PlayerInfo) (AudioClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client, ok := s.clients[player.IP]; ok {
+		return client, nil
+	}
+	client, err := newClientForPlayer(player)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[player.IP] = client
+	return client, nil
+}
+
+func (s *apiServer) handlePlayers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "use GET")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.knownPlayers())
+}
+
+// /api/players/{player}/{action}
+func (s *apiServer) handlePlayer(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/players/"), "/"), "/")
+
+	if len(parts) == 1 && parts[0] == "rescan" {
+		if r.Method != http.MethodPost {
+			writeAPIError(w, http.StatusMethodNotAllowed, "use POST")
+			return
+		}
+		if err := s.rescan(); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, s.knownPlayers())
+		return
+	}
+
+	if len(parts) != 2 {
+		writeAPIError(w, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+
+	player, ok := findPlayer(s.knownPlayers(), parts[0])
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("player %q not found", parts[0]))
+		return
+	}
+	client, err := s.clientFor(player)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.controlMu.Lock()
+	defer s.controlMu.Unlock()
+
+	action := parts[1]
+	if action == "status" || action == "presets" {
+		if r.Method != http.MethodGet {
+			writeAPIError(w, http.StatusMethodNotAllowed, "use GET")
+			return
+		}
+	} else if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+
+	switch action {
+	case "status":
+		status, err := client.GetStatus()
+		respond(w, status, err)
+
+	case "presets":
+		presets, err := client.GetPresets()
+		respond(w, presets, err)
+
+	case "play":
+		var req playRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		switch {
+		case req.URL != "":
+			err = client.PlayURL(req.URL, req.Title)
+		case req.Preset > 0:
+			err = client.PlayPreset(req.Preset)
+		default:
+			err = client.Play()
+		}
+		s.respondStatus(w, client, err)
+
+	case "pause":
+		s.respondStatus(w, client, client.Pause())
+
+	case "stop":
+		s.respondStatus(w, client, client.Stop())
+
+	case "next":
+		s.respondStatus(w, client, client.Next())
+
+	case "prev", "previous":
+		s.respondStatus(w, client, client.Previous())
+
+	case "volume":
+		var req volumeRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		level := req.Delta
+		if req.Level != nil {
+			level = *req.Level
+		} else {
+			status, err := client.GetStatus()
+			if err != nil {
+				writeAPIError(w, http.StatusBadGateway, err.Error())
+				return
+			}
+			level += status.Volume
+		}
+		if level < 0 {
+			level = 0
+		}
+		cancelFade(client)
+		s.respondStatus(w, client, client.SetVolume(minInt(100, level)))
+
+	case "group":
+		var req groupRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		for _, name := range req.Slaves {
+			slave, ok := findPlayer(s.knownPlayers(), name)
+			if !ok {
+				writeAPIError(w, http.StatusNotFound, fmt.Sprintf("player %q not found", name))
+				return
+			}
+			if err := client.AddSlave(slave.IP); err != nil {
+				writeAPIError(w, http.StatusBadGateway, err.Error())
+				return
+			}
+		}
+		s.respondStatus(w, client, nil)
+
+	case "ungroup":
+		err := client.RemoveAllSlaves()
+		if err != nil {
+			err = client.LeaveGroup()
+		}
+		s.respondStatus(w, client, err)
+
+	case "command":
+		var req commandRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		if !apiCommandAllowed(req.Command) {
+			writeAPIError(w, http.StatusForbidden, fmt.Sprintf("command %q is not available through the API", req.Command))
+			return
+		}
+		result, failed := s.runCommand(player, client, req.Command)
+		status := http.StatusOK
+		if failed {
+			status = http.StatusBadRequest
+		}
+		writeJSON(w, status, commandResponse{Result: result})
+
+	default:
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("unknown action %q", action))
+	}
+}
+
+func apiCommandAllowed(command string) bool {
+	fields := strings.Fields(command)
+	return len(fields) > 0 && slices.Contains(apiCommands, strings.ToLower(fields[0]))
+}
+
+// Run a TUI command against the player (controlMu held); returns its message
+// and whether it failed
+func (s *apiServer) runCommand(player PlayerInfo, client AudioClient, command string) (string, bool) {
+	useClient(client, player.Name)
+	tuiState.availablePlayers = s.knownPlayers()
+	tuiState.lastAction = ""
+	executeCommand(command)
+
+	return tuiState.lastAction, tuiState.lastOutcome != outcomeOK
+}
+
+func (s *apiServer) handleScenes(w http.ResponseWriter, r *http.Request) {
+	scenes, err := loadScenes()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/scenes"), "/")
+	if name == "" {
+		if r.Method != http.MethodGet {
+			writeAPIError(w, http.StatusMethodNotAllowed, "use GET")
+			return
+		}
+		if scenes == nil {
+			scenes = []Scene{}
+		}
+		writeJSON(w, http.StatusOK, scenes)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+	scene, ok := findScene(scenes, name)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("scene %q not found", name))
+		return
+	}
+	s.controlMu.Lock()
+	err = applyScene(scene, s.knownPlayers(), s.clientFor)
+	s.controlMu.Unlock()
+	if err != nil {
+		writeAPIError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, scene)
+}
+
+// Answer a control request with the player's new status
+func (s *apiServer) respondStatus(w http.ResponseWriter, client AudioClient, err error) {
+	if err != nil {
+		writeAPIError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	status, err := client.GetStatus()
+	respond(w, status, err)
+}
+
+func respond(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		writeAPIError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// Refuse POST requests that aren't sent as JSON, see the top of this file
+func requireJSON(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/json" {
+				writeAPIError(w, http.StatusUnsupportedMediaType, "use Content-Type: application/json")
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// Neither API authenticates its clients, so anyone who can reach a
+// non-loopback address can control the players
+func warnUnauthenticated(api, addr string) {
+	log.Printf("warning: the %s on %s is reachable from the network and has no authentication", api, addr)
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Decode an optional JSON body
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil && err != io.EOF {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Error: message})
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How long the app-side sleep timer fades out before stopping
+const sleepFadeDuration = 30 * time.Second
+
+// App-side sleep timer for players whose own timer can't be used (e.g.
+// durations BluOS doesn't offer). Only works while the app keeps running.
+type appSleepTimer struct {
+	client   AudioClient
+	deadline time.Time
+	cancel   chan struct{}
+}
+
+var (
+	sleepTimerMu sync.Mutex
+	sleepTimer   *appSleepTimer
+)
+
+func startAppSleepTimer(client AudioClient, d time.Duration) {
+	cancelAppSleepTimer()
+
+	timer := &appSleepTimer{
+		client:   client,
+		deadline: time.Now().Add(d),
+		cancel:   make(chan struct{}),
+	}
+
+	sleepTimerMu.Lock()
+	sleepTimer = timer
+	sleepTimerMu.Unlock()
+
+	go timer.run()
+}
+
+func cancelAppSleepTimer() {
+	sleepTimerMu.Lock()
+	defer sleepTimerMu.Unlock()
+
+	if sleepTimer != nil {
+		close(sleepTimer.cancel)
+		sleepTimer = nil
+	}
+}
+
+func (t *appSleepTimer) run() {
+	fadeStart := time.Until(t.deadline) - sleepFadeDuration
+	if fadeStart < 0 {
+		fadeStart = 0
+	}
+
+	select {
+	case <-time.After(fadeStart):
+	case <-t.cancel:
+		return
+	}
+
+	stopped := fadeOutAndStop(t.client, time.Until(t.deadline))
+	select {
+	case <-stopped:
+	case <-t.cancel:
+		cancelFade(t.client)
+	}
+
+	sleepTimerMu.Lock()
+	if sleepTimer == t {
+		sleepTimer = nil
+	}
+	sleepTimerMu.Unlock()
+}
+
+// Remaining sleep time of the current player (0 when no timer is set)
+func sleepRemaining() time.Duration {
+	sleepTimerMu.Lock()
+	timer := sleepTimer
+	sleepTimerMu.Unlock()
+
+	if timer != nil && timer.client == tuiState.client {
+		if remaining := time.Until(timer.deadline); remaining > 0 {
+			return remaining
+		}
+		return 0
+	}
+
+	if player, ok := tuiState.client.(SleepTimer); ok {
+		if remaining, err := player.SleepTimerRemaining(); err == nil {
+			return remaining
+		}
+	}
+	return 0
+}
+
+func formatSleepDuration(d time.Duration) string {
+	if d >= time.Minute {
+		return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	}
+	return d.Round(time.Second).String()
+}
+
+// Durations are given as Go durations ("30m", "1h30m") or plain minutes
+func parseSleepDuration(arg string) (time.Duration, bool) {
+	if minutes, err := strconv.Atoi(arg); err == nil {
+		return time.Duration(minutes) * time.Minute, minutes > 0
+	}
+	d, err := time.ParseDuration(arg)
+	return d, err == nil && d > 0
+}
+
+// Handle "sleep [<duration>|off|status]"
+func handleSleepCommand(args []string) {
+	if len(args) == 0 || strings.ToLower(args[0]) == "status" {
+		tuiState.sleepRemaining = sleepRemaining()
+		if tuiState.sleepRemaining > 0 {
+			tuiState.lastAction = fmt.Sprintf(getText("sleep_remaining"), formatSleepDuration(tuiState.sleepRemaining))
+		} else {
+			tuiState.lastAction = getText("sleep_none")
+		}
+		return
+	}
+
+	if strings.ToLower(args[0]) == "off" {
+		cancelAppSleepTimer()
+		if player, ok := tuiState.client.(SleepTimer); ok {
+			if err := player.SetSleepTimer(0); err != nil {
+				reportError(fmt.Sprintf("%s: %v", getText("error_sleep_timer"), err))
+				return
+			}
+		}
+		tuiState.sleepRemaining = 0
+		tuiState.lastAction = getText("sleep_off")
+		return
+	}
+
+	d, ok := parseSleepDuration(args[0])
+	if !ok {
+		reportUsage(getText("sleep_usage"))
+		return
+	}
+
+	// Prefer the player's own timer, it keeps working without the app
+	cancelAppSleepTimer()
+	if player, ok := tuiState.client.(SleepTimer); ok {
+		if err := player.SetSleepTimer(d); err == nil {
+			tuiState.sleepRemaining = d
+			tuiState.lastAction = fmt.Sprintf(getText("sleep_set"), formatSleepDuration(d))
+			return
+		}
+	}
+
+	startAppSleepTimer(tuiState.client, d)
+	tuiState.sleepRemaining = d
+	tuiState.lastAction = fmt.Sprintf(getText("sleep_set_app"), formatSleepDuration(d))
+}
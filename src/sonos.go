@@ -6,7 +6,9 @@ import (
 	"html"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -24,12 +26,60 @@ type SonosBody struct {
 	GetTransportInfo  SonosGetTransportInfoBody `xml:"GetTransportInfoResponse"`
 	GetVolumeResponse SonosGetVolumeBody        `xml:"GetVolumeResponse"`
 	Browse            SonosBrowseBody           `xml:"BrowseResponse"`
+	GetMediaInfo      SonosGetMediaInfoBody     `xml:"GetMediaInfoResponse"`
+	GetSleepTimer     SonosGetSleepTimerBody    `xml:"GetRemainingSleepTimerDurationResponse"`
+	RampToVolume      SonosRampToVolumeBody     `xml:"RampToVolumeResponse"`
+	ListAlarms        SonosListAlarmsBody       `xml:"ListAlarmsResponse"`
 }
 
 type SonosGetPositionInfoBody struct {
 	XMLName       xml.Name `xml:"GetPositionInfoResponse"`
 	Track         string   `xml:"Track"`
 	TrackMetaData string   `xml:"TrackMetaData"`
+	TrackURI      string   `xml:"TrackURI"`
+	TrackDuration string   `xml:"TrackDuration"`
+	RelTime       string   `xml:"RelTime"`
+}
+
+type SonosGetMediaInfoBody struct {
+	XMLName            xml.Name `xml:"GetMediaInfoResponse"`
+	CurrentURI         string   `xml:"CurrentURI"`
+	CurrentURIMetaData string   `xml:"CurrentURIMetaData"`
+}
+
+type SonosGetSleepTimerBody struct {
+	XMLName                     xml.Name `xml:"GetRemainingSleepTimerDurationResponse"`
+	RemainingSleepTimerDuration string   `xml:"RemainingSleepTimerDuration"`
+}
+
+type SonosRampToVolumeBody struct {
+	XMLName  xml.Name `xml:"RampToVolumeResponse"`
+	RampTime int      `xml:"RampTime"`
+}
+
+type SonosListAlarmsBody struct {
+	XMLName          xml.Name `xml:"ListAlarmsResponse"`
+	CurrentAlarmList string   `xml:"CurrentAlarmList"`
+}
+
+// Alarms as listed by the AlarmClock service (for all rooms)
+type SonosAlarmList struct {
+	XMLName xml.Name     `xml:"Alarms"`
+	Alarms  []SonosAlarm `xml:"Alarm"`
+}
+
+type SonosAlarm struct {
+	ID                 int    `xml:"ID,attr"`
+	StartTime          string `xml:"StartTime,attr"`
+	Duration           string `xml:"Duration,attr"`
+	Recurrence         string `xml:"Recurrence,attr"`
+	Enabled            string `xml:"Enabled,attr"`
+	RoomUUID           string `xml:"RoomUUID,attr"`
+	ProgramURI         string `xml:"ProgramURI,attr"`
+	ProgramMetaData    string `xml:"ProgramMetaData,attr"`
+	PlayMode           string `xml:"PlayMode,attr"`
+	Volume             int    `xml:"Volume,attr"`
+	IncludeLinkedZones string `xml:"IncludeLinkedZones,attr"`
 }
 
 type SonosGetTransportInfoBody struct {
@@ -43,16 +93,20 @@ type SonosGetVolumeBody struct {
 }
 
 type SonosBrowseBody struct {
-	XMLName xml.Name `xml:"BrowseResponse"`
-	Result  string   `xml:"Result"`
+	XMLName        xml.Name `xml:"BrowseResponse"`
+	Result         string   `xml:"Result"`
+	NumberReturned int      `xml:"NumberReturned"`
+	TotalMatches   int      `xml:"TotalMatches"`
+	UpdateID       int      `xml:"UpdateID"`
 }
 
 // Sonos favorite item structure
 type SonosFavorite struct {
-	ID   int
-	Name string
-	URI  string
-	Meta string
+	ID       int
+	ObjectID string
+	Name     string
+	URI      string
+	Meta     string
 }
 
 // Sonos API Client
@@ -60,6 +114,11 @@ type SonosClient struct {
 	baseURL   string
 	client    *http.Client
 	favorites []SonosFavorite
+	udn       string
+
+	// UpdateIDs of the favorite containers when the favorites were loaded
+	favoritesUpdateIDs map[string]int
+	favoritesChecked   time.Time
 }
 
 func NewSonosClient(ip string) *SonosClient {
@@ -74,12 +133,25 @@ func NewSonosClient(ip string) *SonosClient {
 
 // Sonos API methods
 func (sc *SonosClient) makeSoapRequest(action, service, body string) ([]byte, error) {
+	return sc.makeSoapRequestAt(fmt.Sprintf("/MediaRenderer/%s/Control", service), action, service, body)
+}
+
+// ContentDirectory lives under /MediaServer rather than /MediaRenderer
+func (sc *SonosClient) makeContentDirectoryRequest(action, body string) ([]byte, error) {
+	return sc.makeSoapRequestAt("/MediaServer/ContentDirectory/Control", action, "ContentDirectory", body)
+}
+
+func (sc *SonosClient) makeAlarmClockRequest(action, body string) ([]byte, error) {
+	return sc.makeSoapRequestAt("/AlarmClock/Control", action, "AlarmClock", body)
+}
+
+func (sc *SonosClient) makeSoapRequestAt(controlPath, action, service, body string) ([]byte, error) {
 	soapEnvelope := fmt.Sprintf(`<?xml version="1.0"?>
 <s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
 <s:Body>%s</s:Body>
 </s:Envelope>`, body)
 
-	url := fmt.Sprintf("%s/MediaRenderer/%s/Control", sc.baseURL, service)
+	url := sc.baseURL + controlPath
 	req, err := http.NewRequest("POST", url, strings.NewReader(soapEnvelope))
 	if err != nil {
 		return nil, err
@@ -89,225 +161,160 @@ func (sc *SonosClient) makeSoapRequest(action, service, body string) ([]byte, er
 	req.Header.Set("SOAPAction", fmt.Sprintf(`"urn:schemas-upnp-org:service:%s:1#%s"`, service, action))
 	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(soapEnvelope)))
 
+	start, errorKind := time.Now(), ""
+	defer func() { observeRequest("sonos", action, start, errorKind) }()
+
 	resp, err := sc.client.Do(req)
 	if err != nil {
+		errorKind = requestErrorKind(err)
 		return nil, fmt.Errorf("SOAP request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		// UPnP errors arrive as SOAP faults with status 500
+		errorKind = "http_status"
+		if resp.StatusCode == http.StatusInternalServerError {
+			errorKind = "soap_fault"
+		}
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("SOAP request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		errorKind = "read"
+	}
+	return data, err
 }
 
-func (sc *SonosClient) loadFavorites() error {
-	if len(sc.favorites) > 0 {
-		return nil // Already loaded
-	}
+// Saved radio stations (R:0/0) are numbered after this offset so their IDs
+// never collide with those of Sonos favorites (FV:2)
+const radioFavoriteIDOffset = 1000
 
-	// Force clear cache to reload
-	sc.favorites = nil
+// Containers favorites are loaded from: Sonos favorites of every type,
+// followed by saved radio stations
+var sonosFavoriteContainers = []string{"FV:2", "R:0/0"}
 
-	// First, try to get Sonos Radio favorites (R:0/0)
-	radioFavorites := sc.browseSonosRadioStations()
+// How often cached favorites are checked for changes on the player
+const favoritesCheckInterval = 30 * time.Second
 
-	if len(radioFavorites) > 0 {
-		sc.favorites = radioFavorites
-		return nil
+func (sc *SonosClient) loadFavorites() error {
+	if len(sc.favorites) > 0 && !sc.favoritesChanged() {
+		return nil // Still current
 	}
 
-	// If no radio favorites found, create informative entry
-	sc.favorites = []SonosFavorite{
-		{ID: 1, Name: "[INFO] No Sonos Radio favorites found", URI: "", Meta: ""},
-		{ID: 2, Name: "[INFO] Add radio stations in the Sonos app", URI: "", Meta: ""},
+	sc.favorites = nil
+	sc.favoritesUpdateIDs = make(map[string]int)
+	sc.favoritesChecked = time.Now()
+	for _, objectID := range sonosFavoriteContainers {
+		if updateID, err := sc.containerUpdateID(objectID); err == nil {
+			sc.favoritesUpdateIDs[objectID] = updateID
+		}
 	}
 
-	return nil
-}
+	for _, objectID := range sonosFavoriteContainers {
+		items, _, err := sc.browseAll(objectID)
+		if err != nil {
+			continue
+		}
 
-func (sc *SonosClient) browseSonosRadioStations() []SonosFavorite {
-	var allRadioFavorites []SonosFavorite
+		for _, item := range items {
+			fav := favoriteFromDIDL(item)
+			if fav.URI == "" || fav.ID == 0 {
+				continue
+			}
 
-	// Try different ObjectIDs for Sonos Radio stations
-	radioObjectIDs := []struct {
-		id   string
-		name string
-	}{
-		{"R:0/0", "Sonos Radio"},
-		{"R:0/1", "Radio Stations"},
-		{"FV:2", "Favorites"}, // Sometimes radio stations are in general favorites
-		{"A:RADIO", "Radio"},
-		{"SQ:", "Sonos Favorites"},
-	}
-
-	for _, obj := range radioObjectIDs {
-		favorites := sc.browseForRadioContent(obj.id)
-
-		// Filter to only include radio stations
-		for _, fav := range favorites {
-			if sc.isRadioStation(fav) {
-				// Check if not already in the list
-				isDuplicate := false
-				for _, existing := range allRadioFavorites {
-					if existing.URI == fav.URI || existing.Name == fav.Name {
-						isDuplicate = true
-						break
-					}
-				}
-				if !isDuplicate {
-					allRadioFavorites = append(allRadioFavorites, fav)
+			isDuplicate := false
+			for _, existing := range sc.favorites {
+				if existing.URI == fav.URI {
+					isDuplicate = true
+					break
 				}
 			}
+			if !isDuplicate {
+				sc.favorites = append(sc.favorites, fav)
+			}
 		}
 	}
 
-	// Re-number the favorites
-	for i := range allRadioFavorites {
-		allRadioFavorites[i].ID = i + 1
-	}
-
-	return allRadioFavorites
-}
-
-func (sc *SonosClient) browseForRadioContent(objectID string) []SonosFavorite {
-	body := fmt.Sprintf(`<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
-		<ObjectID>%s</ObjectID>
-		<BrowseFlag>BrowseDirectChildren</BrowseFlag>
-		<Filter>*</Filter>
-		<StartingIndex>0</StartingIndex>
-		<RequestedCount>100</RequestedCount>
-		<SortCriteria></SortCriteria>
-	</u:Browse>`, objectID)
-
-	soapEnvelope := fmt.Sprintf(`<?xml version="1.0"?>
-<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
-<s:Body>%s</s:Body>
-</s:Envelope>`, body)
-
-	// Try MediaServer path
-	url := fmt.Sprintf("%s/MediaServer/ContentDirectory/Control", sc.baseURL)
-	req, err := http.NewRequest("POST", url, strings.NewReader(soapEnvelope))
-	if err != nil {
-		return []SonosFavorite{}
-	}
-
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.Header.Set("SOAPAction", `"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`)
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(soapEnvelope)))
-
-	resp, err := sc.client.Do(req)
-	if err != nil {
-		return []SonosFavorite{}
+	if len(sc.favorites) > 0 {
+		sort.SliceStable(sc.favorites, func(i, j int) bool {
+			return sc.favorites[i].ID < sc.favorites[j].ID
+		})
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return []SonosFavorite{}
+	// If no favorites found, create informative entry
+	sc.favorites = []SonosFavorite{
+		{ID: 1, Name: "[INFO] No Sonos favorites found", URI: "", Meta: ""},
+		{ID: 2, Name: "[INFO] Add favorites in the Sonos app", URI: "", Meta: ""},
 	}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return []SonosFavorite{}
-	}
+	return nil
+}
 
-	return sc.parseFavoritesFromResponse(string(data))
+// Drop the cached favorites so the next access reloads them
+func (sc *SonosClient) RefreshPresets() {
+	sc.favorites = nil
 }
 
-func (sc *SonosClient) isRadioStation(fav SonosFavorite) bool {
-	// Check URI patterns that indicate radio stations
-	radioPatterns := []string{
-		"x-sonosapi-stream:",
-		"x-sonosapi-radio:",
-		"x-rincon-mp3radio:",
-		"http://", // Many radio stations use direct HTTP streams
-		"https://",
-		"mms://",
-		"rtsp://",
-		"x-sonos-http:",
+// A container's UpdateID changes whenever an entry is added, removed or
+// renamed, e.g. from the Sonos app. Each check costs a request per
+// container, so it's done at most every favoritesCheckInterval.
+func (sc *SonosClient) favoritesChanged() bool {
+	if time.Since(sc.favoritesChecked) < favoritesCheckInterval {
+		return false
 	}
+	sc.favoritesChecked = time.Now()
 
-	for _, pattern := range radioPatterns {
-		if strings.HasPrefix(fav.URI, pattern) {
+	for _, objectID := range sonosFavoriteContainers {
+		updateID, err := sc.containerUpdateID(objectID)
+		if err == nil && updateID != sc.favoritesUpdateIDs[objectID] {
 			return true
 		}
 	}
+	return false
+}
 
-	// Check metadata for radio-related classes
-	if strings.Contains(fav.Meta, "object.item.audioItem.audioBroadcast") ||
-		strings.Contains(fav.Meta, "object.item.audioItem.radio") ||
-		strings.Contains(fav.Meta, "radioBroadcast") {
-		return true
+func (sc *SonosClient) containerUpdateID(objectID string) (int, error) {
+	_, info, err := sc.browseContentDirectory(objectID, 0, 1)
+	if err != nil {
+		return 0, err
 	}
+	return info.UpdateID, nil
+}
 
-	// Check if the name suggests it's a radio station
-	radioNamePatterns := []string{
-		"Radio",
-		"FM",
-		"AM",
-		"Stream",
-		"Live",
+// Stable favorite ID from the Sonos object ID ("FV:2/13" -> 13)
+func favoriteIDFromObjectID(objectID string) int {
+	idx := strings.LastIndex(objectID, "/")
+	if idx == -1 {
+		return 0
 	}
-
-	nameLower := strings.ToLower(fav.Name)
-	for _, pattern := range radioNamePatterns {
-		if strings.Contains(nameLower, strings.ToLower(pattern)) {
-			return true
-		}
+	n, err := strconv.Atoi(objectID[idx+1:])
+	if err != nil {
+		return 0
 	}
-
-	return false
+	if strings.HasPrefix(objectID, "R:0/0/") {
+		n += radioFavoriteIDOffset
+	}
+	return n
 }
 
-func (sc *SonosClient) parseFavoritesFromResponse(xmlResponse string) []SonosFavorite {
-	var favorites []SonosFavorite
-
-	// Look for the Result element in the SOAP response
-	resultRegex := regexp.MustCompile(`<Result>(.*?)</Result>`)
-	resultMatch := resultRegex.FindStringSubmatch(xmlResponse)
-
-	if len(resultMatch) < 2 {
-		return favorites
+// Favorites carry the playable item's DIDL in resMD; other containers
+// (e.g. R:0/0) only describe themselves, so metadata is rebuilt from the item.
+func favoriteFromDIDL(item DIDLObject) SonosFavorite {
+	meta := item.ResMD
+	if meta == "" {
+		meta = didlForObject(item)
 	}
-
-	// Decode the DIDL-Lite content
-	didlContent := html.UnescapeString(resultMatch[1])
-
-	// Parse items from DIDL-Lite
-	itemRegex := regexp.MustCompile(`<item[^>]*id="([^"]*)"[^>]*>(.*?)</item>`)
-	titleRegex := regexp.MustCompile(`<dc:title[^>]*>(.*?)</dc:title>`)
-	resRegex := regexp.MustCompile(`<res[^>]*>(.*?)</res>`)
-
-	items := itemRegex.FindAllStringSubmatch(didlContent, -1)
-
-	for i, item := range items {
-		if len(item) > 2 {
-			itemContent := item[2]
-
-			var title, uri string
-
-			if titleMatch := titleRegex.FindStringSubmatch(itemContent); len(titleMatch) > 1 {
-				title = html.UnescapeString(titleMatch[1])
-			}
-
-			if resMatch := resRegex.FindStringSubmatch(itemContent); len(resMatch) > 1 {
-				uri = html.UnescapeString(resMatch[1])
-			}
-
-			if title != "" {
-				favorites = append(favorites, SonosFavorite{
-					ID:   i + 1,
-					Name: strings.TrimSpace(title),
-					URI:  uri,
-					Meta: itemContent,
-				})
-			}
-		}
+	return SonosFavorite{
+		ID:       favoriteIDFromObjectID(item.ID),
+		ObjectID: item.ID,
+		Name:     strings.TrimSpace(item.Title),
+		URI:      item.Res,
+		Meta:     meta,
 	}
-
-	return favorites
 }
 
 func (sc *SonosClient) GetPresets() ([]Preset, error) {
@@ -404,12 +411,17 @@ func (sc *SonosClient) GetStatus() (*Status, error) {
 		}
 	}
 
+	position := positionResponse.Body.GetPositionInfo
 	return &Status{
-		State:  state,
-		Song:   song,
-		Artist: artist,
-		Album:  album,
-		Volume: volume,
+		State:     state,
+		Song:      song,
+		Artist:    artist,
+		Album:     album,
+		Volume:    volume,
+		StreamURL: position.TrackURI,
+		Image:     sc.artworkURL(parseSonosAlbumArt(metadata)),
+		Position:  int(parseSonosDuration(position.RelTime).Seconds()),
+		Duration:  int(parseSonosDuration(position.TrackDuration).Seconds()),
 	}, nil
 }
 
@@ -431,6 +443,13 @@ func parseSonosMetadata(metadata string) (song, artist, album string) {
 	return song, artist, album
 }
 
+func parseSonosAlbumArt(metadata string) string {
+	if match := regexp.MustCompile(`<upnp:albumArtURI[^>]*>(.*?)</upnp:albumArtURI>`).FindStringSubmatch(metadata); len(match) > 1 {
+		return html.UnescapeString(match[1])
+	}
+	return ""
+}
+
 func (sc *SonosClient) PlayPreset(id int) error {
 	if err := sc.loadFavorites(); err != nil {
 		return err
@@ -458,59 +477,49 @@ func (sc *SonosClient) PlayPreset(id int) error {
 		return fmt.Errorf("no URI available for this favorite")
 	}
 
-	// For Sonos favorites, we need to use the original metadata from the browse response
-	// The key is to preserve the exact metadata structure that Sonos expects
-	var metadata string
-	if favorite.Meta != "" {
-		// Use the original metadata wrapped in DIDL-Lite envelope
-		metadata = fmt.Sprintf(`&lt;DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"&gt;&lt;item id="FAVORITE"&gt;%s&lt;/item&gt;&lt;/DIDL-Lite&gt;`,
-			strings.ReplaceAll(strings.ReplaceAll(favorite.Meta, "<", "&lt;"), ">", "&gt;"))
-	} else {
-		// Create minimal valid metadata for radio stations
-		metadata = `&lt;DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"&gt;&lt;item id="R:0/0"&gt;&lt;dc:title&gt;` + html.EscapeString(favorite.Name) + `&lt;/dc:title&gt;&lt;upnp:class&gt;object.item.audioItem.audioBroadcast&lt;/upnp:class&gt;&lt;/item&gt;&lt;/DIDL-Lite&gt;`
+	if err := sc.playURI(favorite.URI, favorite.Meta); err != nil {
+		// Try alternative approach for radio streams
+		if strings.Contains(favorite.URI, "x-sonosapi") || strings.Contains(favorite.URI, "radio") {
+			return sc.playRadioStation(favorite)
+		}
+		return err
 	}
 
-	// Create SOAP request with proper XML escaping
-	body := fmt.Sprintf(`<u:SetAVTransportURI xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
-		<InstanceID>0</InstanceID>
-		<CurrentURI>%s</CurrentURI>
-		<CurrentURIMetaData>%s</CurrentURIMetaData>
-	</u:SetAVTransportURI>`, html.EscapeString(favorite.URI), metadata)
-
-	soapEnvelope := fmt.Sprintf(`<?xml version="1.0"?>
-<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
-<s:Body>%s</s:Body>
-</s:Envelope>`, body)
+	return nil
+}
 
-	url := fmt.Sprintf("%s/MediaRenderer/AVTransport/Control", sc.baseURL)
-	req, err := http.NewRequest("POST", url, strings.NewReader(soapEnvelope))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+// Play a URI with its DIDL metadata. Containers (albums, playlists, artists)
+// can't be set as transport URI directly and are played through the queue.
+func (sc *SonosClient) playURI(uri, meta string) error {
+	if isSonosContainerURI(uri, meta) {
+		if err := sc.ClearQueue(); err != nil {
+			return err
+		}
+		if err := sc.addURIToQueue(uri, meta, false); err != nil {
+			return err
+		}
+		return sc.PlayQueueItem(1)
 	}
 
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.Header.Set("SOAPAction", `"urn:schemas-upnp-org:service:AVTransport:1#SetAVTransportURI"`)
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(soapEnvelope)))
-
-	resp, err := sc.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("SOAP request failed: %w", err)
+	if err := sc.setAVTransportURI(uri, meta); err != nil {
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
+	return sc.Play()
+}
 
-		// Try alternative approach for radio streams
-		if strings.Contains(favorite.URI, "x-sonosapi") || strings.Contains(favorite.URI, "radio") {
-			return sc.playRadioStation(favorite)
+func isSonosContainerURI(uri, meta string) bool {
+	containerPrefixes := []string{
+		"x-rincon-cpcontainer:",
+		"x-rincon-playlist:",
+		"file:///jffs/settings/savedqueues.rsq",
+	}
+	for _, prefix := range containerPrefixes {
+		if strings.HasPrefix(uri, prefix) {
+			return true
 		}
-
-		return fmt.Errorf("SetAVTransportURI failed with status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
-
-	// Start playback
-	return sc.Play()
+	return strings.Contains(meta, "object.container")
 }
 
 func (sc *SonosClient) playRadioStation(favorite *SonosFavorite) error {
@@ -565,6 +574,162 @@ func (sc *SonosClient) playRadioStation(favorite *SonosFavorite) error {
 	return sc.Play()
 }
 
+func (sc *SonosClient) PlayURL(streamURL, title string) error {
+	resolved, err := resolveStreamURL(streamURL)
+	if err != nil {
+		return err
+	}
+
+	if title == "" {
+		title = streamTitle(resolved)
+	}
+
+	uri, metadata := sonosStreamURI(resolved, title)
+	if err := sc.setAVTransportURI(uri, metadata); err != nil {
+		return err
+	}
+
+	return sc.Play()
+}
+
+// Map a plain stream URL to the URI scheme and metadata Sonos expects.
+// Radio streams need x-rincon-mp3radio, otherwise Sonos treats them as a
+// finite file and gives up once its buffer runs dry. Sonos fetches
+// x-rincon-mp3radio over plain HTTP, so HTTPS streams keep their URL.
+func sonosStreamURI(streamURL, title string) (string, string) {
+	if isAudioFileURL(streamURL) || !strings.HasPrefix(streamURL, "http") {
+		return streamURL, buildDIDLMetadata("-1", title, "object.item.audioItem.musicTrack", "")
+	}
+
+	uri := streamURL
+	if rest, ok := strings.CutPrefix(streamURL, "http://"); ok {
+		uri = "x-rincon-mp3radio://" + rest
+	}
+	return uri, buildDIDLMetadata("R:0/0/0", title, "object.item.audioItem.audioBroadcast", "SA_RINCON65031_")
+}
+
+func (sc *SonosClient) setAVTransportURI(uri, metadata string) error {
+	body := fmt.Sprintf(`<u:SetAVTransportURI xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<CurrentURI>%s</CurrentURI>
+		<CurrentURIMetaData>%s</CurrentURIMetaData>
+	</u:SetAVTransportURI>`, html.EscapeString(uri), html.EscapeString(metadata))
+
+	_, err := sc.makeSoapRequest("SetAVTransportURI", "AVTransport", body)
+	return err
+}
+
+// Favorite management (ContentDirectory objects in FV:2)
+func (sc *SonosClient) currentMedia() (string, string, error) {
+	body := `<u:GetMediaInfo xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+	</u:GetMediaInfo>`
+
+	data, err := sc.makeSoapRequest("GetMediaInfo", "AVTransport", body)
+	if err != nil {
+		return "", "", err
+	}
+
+	var response SonosGetPositionInfoResponse
+	if err := xml.Unmarshal(data, &response); err != nil {
+		return "", "", fmt.Errorf("failed to parse media info: %w", err)
+	}
+
+	return response.Body.GetMediaInfo.CurrentURI, response.Body.GetMediaInfo.CurrentURIMetaData, nil
+}
+
+func (sc *SonosClient) CurrentStream() (*Preset, error) {
+	uri, meta, err := sc.currentMedia()
+	if err != nil {
+		return nil, err
+	}
+	if uri == "" {
+		return nil, fmt.Errorf("no stream playing")
+	}
+
+	name, _, _ := parseSonosMetadata(meta)
+	if name == "" {
+		name = streamTitle(uri)
+	}
+
+	return &Preset{Name: name, URL: uri}, nil
+}
+
+// Sonos favorites are appended; the requested slot is ignored
+func (sc *SonosClient) SavePreset(preset Preset) error {
+	uri, meta := preset.URL, ""
+	if currentURI, currentMeta, err := sc.currentMedia(); err == nil && currentURI == uri {
+		meta = currentMeta
+	} else if strings.HasPrefix(uri, "http") {
+		uri, meta = sonosStreamURI(uri, preset.Name)
+	} else {
+		meta = buildDIDLMetadata("R:0/0/0", preset.Name, "object.item.audioItem.audioBroadcast", "SA_RINCON65031_")
+	}
+
+	body := fmt.Sprintf(`<u:CreateObject xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+		<ContainerID>FV:2</ContainerID>
+		<Elements>%s</Elements>
+	</u:CreateObject>`, html.EscapeString(buildFavoriteDIDL(preset.Name, uri, meta, preset.Image)))
+
+	_, err := sc.makeContentDirectoryRequest("CreateObject", body)
+	sc.favorites = nil
+	return err
+}
+
+// Only entries of FV:2 can be edited; saved radio stations can't
+func (sc *SonosClient) findEditableFavorite(id int) (*SonosFavorite, error) {
+	if err := sc.loadFavorites(); err != nil {
+		return nil, err
+	}
+	for _, fav := range sc.favorites {
+		if fav.ID == id {
+			if !strings.HasPrefix(fav.ObjectID, "FV:2/") {
+				return nil, fmt.Errorf("this entry is not a Sonos favorite")
+			}
+			return &fav, nil
+		}
+	}
+	return nil, fmt.Errorf("favorite not found")
+}
+
+func (sc *SonosClient) RenamePreset(id int, name string) error {
+	fav, err := sc.findEditableFavorite(id)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`<u:UpdateObject xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+		<ObjectID>%s</ObjectID>
+		<CurrentTagValue>%s</CurrentTagValue>
+		<NewTagValue>%s</NewTagValue>
+	</u:UpdateObject>`, html.EscapeString(fav.ObjectID),
+		html.EscapeString("<dc:title>"+html.EscapeString(fav.Name)+"</dc:title>"),
+		html.EscapeString("<dc:title>"+html.EscapeString(name)+"</dc:title>"))
+
+	_, err = sc.makeContentDirectoryRequest("UpdateObject", body)
+	sc.favorites = nil
+	return err
+}
+
+func (sc *SonosClient) DeletePreset(id int) error {
+	fav, err := sc.findEditableFavorite(id)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`<u:DestroyObject xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+		<ObjectID>%s</ObjectID>
+	</u:DestroyObject>`, html.EscapeString(fav.ObjectID))
+
+	_, err = sc.makeContentDirectoryRequest("DestroyObject", body)
+	sc.favorites = nil
+	return err
+}
+
+func (sc *SonosClient) MovePreset(from, to int) error {
+	return fmt.Errorf("Sonos favorites can't be reordered")
+}
+
 func (sc *SonosClient) Play() error {
 	body := `<u:Play xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
 		<InstanceID>0</InstanceID>
@@ -608,26 +773,687 @@ func (sc *SonosClient) SetVolume(level int) error {
 	return err
 }
 
-func (sc *SonosClient) Next() error {
-	body := `<u:Next xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
-		<InstanceID>0</InstanceID>
-	</u:Next>`
+// Typical length of a SLEEP_TIMER_RAMP_TYPE ramp
+const sonosRampDuration = 17 * time.Second
 
-	_, err := sc.makeSoapRequest("Next", "AVTransport", body)
-	return err
+func (sc *SonosClient) RampDuration() time.Duration {
+	return sonosRampDuration
 }
 
-func (sc *SonosClient) Previous() error {
-	body := `<u:Previous xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+// Let the player ramp from the current volume to the target; Sonos picks
+// the speed and reports the ramp time
+func (sc *SonosClient) RampToVolume(target int) (time.Duration, error) {
+	if target < 0 || target > 100 {
+		return 0, fmt.Errorf("volume must be between 0 and 100")
+	}
+
+	body := fmt.Sprintf(`<u:RampToVolume xmlns:u="urn:schemas-upnp-org:service:RenderingControl:1">
 		<InstanceID>0</InstanceID>
-	</u:Previous>`
+		<Channel>Master</Channel>
+		<RampType>SLEEP_TIMER_RAMP_TYPE</RampType>
+		<DesiredVolume>%d</DesiredVolume>
+		<ResetVolumeAfter>0</ResetVolumeAfter>
+		<ProgramURI></ProgramURI>
+	</u:RampToVolume>`, target)
 
-	_, err := sc.makeSoapRequest("Previous", "AVTransport", body)
-	return err
+	data, err := sc.makeSoapRequest("RampToVolume", "RenderingControl", body)
+	if err != nil {
+		return 0, err
+	}
+
+	var response SonosGetPositionInfoResponse
+	if err := xml.Unmarshal(data, &response); err != nil {
+		return 0, fmt.Errorf("failed to parse RampToVolume response: %w", err)
+	}
+	return time.Duration(response.Body.RampToVolume.RampTime) * time.Second, nil
 }
 
-func (sc *SonosClient) AddSlave(slaveIP string) error {
-	// Sonos grouping is more complex - for now, return not implemented
+func (sc *SonosClient) SetSleepTimer(d time.Duration) error {
+	// An empty duration switches the timer off
+	duration := ""
+	if d > 0 {
+		seconds := int(d.Round(time.Second) / time.Second)
+		if seconds >= 24*3600 {
+			return fmt.Errorf("sleep timer must be shorter than 24 hours")
+		}
+		duration = fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+
+	body := fmt.Sprintf(`<u:ConfigureSleepTimer xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<NewSleepTimerDuration>%s</NewSleepTimerDuration>
+	</u:ConfigureSleepTimer>`, duration)
+
+	_, err := sc.makeSoapRequest("ConfigureSleepTimer", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) SleepTimerRemaining() (time.Duration, error) {
+	body := `<u:GetRemainingSleepTimerDuration xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+	</u:GetRemainingSleepTimerDuration>`
+
+	data, err := sc.makeSoapRequest("GetRemainingSleepTimerDuration", "AVTransport", body)
+	if err != nil {
+		return 0, err
+	}
+
+	var response SonosGetPositionInfoResponse
+	if err := xml.Unmarshal(data, &response); err != nil {
+		return 0, fmt.Errorf("failed to parse sleep timer response: %w", err)
+	}
+	return parseSonosDuration(response.Body.GetSleepTimer.RemainingSleepTimerDuration), nil
+}
+
+// Parse Sonos "H:MM:SS" durations (empty means none)
+func parseSonosDuration(value string) time.Duration {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 3 {
+		return 0
+	}
+
+	var total time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0
+		}
+		total += time.Duration(n) * unit
+	}
+	return total
+}
+
+func (sc *SonosClient) Next() error {
+	body := `<u:Next xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+	</u:Next>`
+
+	_, err := sc.makeSoapRequest("Next", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) Previous() error {
+	body := `<u:Previous xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+	</u:Previous>`
+
+	_, err := sc.makeSoapRequest("Previous", "AVTransport", body)
+	return err
+}
+
+// Browse one page of a ContentDirectory container
+func (sc *SonosClient) browseContentDirectory(objectID string, start, count int) (*DIDLLite, *SonosBrowseBody, error) {
+	body := fmt.Sprintf(`<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+		<ObjectID>%s</ObjectID>
+		<BrowseFlag>BrowseDirectChildren</BrowseFlag>
+		<Filter>*</Filter>
+		<StartingIndex>%d</StartingIndex>
+		<RequestedCount>%d</RequestedCount>
+		<SortCriteria></SortCriteria>
+	</u:Browse>`, html.EscapeString(objectID), start, count)
+
+	data, err := sc.makeContentDirectoryRequest("Browse", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response SonosGetPositionInfoResponse
+	if err := xml.Unmarshal(data, &response); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse browse response: %w", err)
+	}
+
+	didl, err := parseDIDL(response.Body.Browse.Result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return didl, &response.Body.Browse, nil
+}
+
+// Browse all pages of a container, returning its items and sub-containers
+func (sc *SonosClient) browseAll(objectID string) ([]DIDLObject, []DIDLObject, error) {
+	var items, containers []DIDLObject
+	for start := 0; ; {
+		didl, info, err := sc.browseContentDirectory(objectID, start, 100)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		items = append(items, didl.Items...)
+		containers = append(containers, didl.Containers...)
+
+		start += info.NumberReturned
+		if info.NumberReturned == 0 || start >= info.TotalMatches {
+			break
+		}
+	}
+
+	return items, containers, nil
+}
+
+// Top level of the Sonos content browser
+var sonosBrowseRoots = []BrowseItem{
+	{Title: "Sonos Favorites", Key: "FV:2"},
+	{Title: "Sonos Playlists", Key: "SQ:"},
+	{Title: "Artists", Key: "A:ARTIST"},
+	{Title: "Albums", Key: "A:ALBUM"},
+	{Title: "Tracks", Key: "A:TRACKS"},
+	{Title: "Music Library Folders", Key: "S:"},
+	{Title: "Radio Stations", Key: "R:0/0"},
+	{Title: "Line-In", Key: "AI:"},
+}
+
+const (
+	sonosBrowsePageSize = 50
+	// Separates object ID and starting index in paged browse keys
+	sonosPageSeparator = "\x1f"
+)
+
+func (sc *SonosClient) Browse(key string) (*BrowseResult, error) {
+	if key == "" {
+		return &BrowseResult{
+			Title: "Sonos",
+			Items: append([]BrowseItem(nil), sonosBrowseRoots...),
+		}, nil
+	}
+
+	objectID, start := key, 0
+	if idx := strings.LastIndex(key, sonosPageSeparator); idx != -1 {
+		objectID = key[:idx]
+		start, _ = strconv.Atoi(key[idx+1:])
+	}
+
+	didl, info, err := sc.browseContentDirectory(objectID, start, sonosBrowsePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BrowseResult{Key: key}
+	for _, container := range didl.Containers {
+		result.Items = append(result.Items, sc.browseItemFromDIDL(container, true))
+	}
+	for _, item := range didl.Items {
+		result.Items = append(result.Items, sc.browseItemFromDIDL(item, false))
+	}
+
+	if next := start + info.NumberReturned; info.NumberReturned > 0 && next < info.TotalMatches {
+		result.NextKey = fmt.Sprintf("%s%s%d", objectID, sonosPageSeparator, next)
+	}
+
+	return result, nil
+}
+
+func (sc *SonosClient) browseItemFromDIDL(obj DIDLObject, container bool) BrowseItem {
+	item := BrowseItem{
+		Title:    obj.Title,
+		Subtitle: obj.Creator,
+		Image:    sc.artworkURL(obj.AlbumArtURI),
+		PlayURI:  obj.Res,
+		Meta:     obj.ResMD,
+	}
+
+	if item.Meta == "" {
+		item.Meta = didlForObject(obj)
+	}
+
+	if container {
+		item.Key = obj.ID
+		// Library containers have no res; they are enqueued by reference
+		if item.PlayURI == "" && (strings.HasPrefix(obj.ID, "A:") || strings.HasPrefix(obj.ID, "S:")) {
+			if udn, err := sc.getUDN(); err == nil {
+				item.PlayURI = fmt.Sprintf("x-rincon-playlist:%s#%s", udn, obj.ID)
+			}
+		}
+	}
+
+	return item
+}
+
+// Search favorites and playlists by name and the music library by prefix
+// browse (A:ARTIST:<term> etc.), which Sonos supports in place of Search.
+func (sc *SonosClient) Search(query string) ([]BrowseItem, error) {
+	var results []BrowseItem
+	needle := strings.ToLower(query)
+
+	for _, key := range []string{"FV:2", "SQ:"} {
+		items, containers, err := sc.browseAll(key)
+		if err != nil {
+			continue
+		}
+		for _, obj := range append(containers, items...) {
+			if strings.Contains(strings.ToLower(obj.Title), needle) {
+				item := sc.browseItemFromDIDL(obj, strings.HasPrefix(obj.Class, "object.container"))
+				item.Source = sonosRootTitle(key)
+				results = append(results, item)
+			}
+		}
+	}
+
+	var lastErr error
+	for _, key := range []string{"A:ARTIST", "A:ALBUM", "A:TRACKS"} {
+		didl, _, err := sc.browseContentDirectory(key+":"+url.PathEscape(query), 0, sonosBrowsePageSize)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, container := range didl.Containers {
+			item := sc.browseItemFromDIDL(container, true)
+			item.Source = sonosRootTitle(key)
+			results = append(results, item)
+		}
+		for _, obj := range didl.Items {
+			item := sc.browseItemFromDIDL(obj, false)
+			item.Source = sonosRootTitle(key)
+			results = append(results, item)
+		}
+	}
+
+	if len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return results, nil
+}
+
+func sonosRootTitle(key string) string {
+	for _, root := range sonosBrowseRoots {
+		if root.Key == key {
+			return root.Title
+		}
+	}
+	return key
+}
+
+// Album art is often returned relative to the player
+func (sc *SonosClient) artworkURL(uri string) string {
+	if strings.HasPrefix(uri, "/") {
+		return sc.baseURL + uri
+	}
+	return uri
+}
+
+func (sc *SonosClient) PlayItem(item BrowseItem) error {
+	if item.PlayURI == "" {
+		return fmt.Errorf("item is not playable")
+	}
+	return sc.playURI(item.PlayURI, item.Meta)
+}
+
+func (sc *SonosClient) QueueItem(item BrowseItem) error {
+	if item.PlayURI == "" {
+		return fmt.Errorf("item cannot be added to the queue")
+	}
+	return sc.addURIToQueue(item.PlayURI, item.Meta, false)
+}
+
+// Unique device name (RINCON_...) used for queue and alarm URIs
+func (sc *SonosClient) getUDN() (string, error) {
+	if sc.udn != "" {
+		return sc.udn, nil
+	}
+
+	resp, err := sc.client.Get(sc.baseURL + "/xml/device_description.xml")
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	match := regexp.MustCompile(`<UDN>uuid:(RINCON_[^<]+)</UDN>`).FindStringSubmatch(string(data))
+	if len(match) < 2 {
+		return "", fmt.Errorf("device UDN not found")
+	}
+
+	sc.udn = match[1]
+	return sc.udn, nil
+}
+
+// Alarms (AlarmClock service)
+func (sc *SonosClient) listSonosAlarms() ([]SonosAlarm, error) {
+	udn, err := sc.getUDN()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := sc.makeAlarmClockRequest("ListAlarms", `<u:ListAlarms xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1"></u:ListAlarms>`)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SonosGetPositionInfoResponse
+	if err := xml.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse ListAlarms response: %w", err)
+	}
+
+	var list SonosAlarmList
+	if err := xml.Unmarshal([]byte(response.Body.ListAlarms.CurrentAlarmList), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse alarm list: %w", err)
+	}
+
+	// Only this room's alarms
+	var alarms []SonosAlarm
+	for _, alarm := range list.Alarms {
+		if alarm.RoomUUID == udn {
+			alarms = append(alarms, alarm)
+		}
+	}
+	return alarms, nil
+}
+
+func (sc *SonosClient) ListAlarms() ([]Alarm, error) {
+	sonosAlarms, err := sc.listSonosAlarms()
+	if err != nil {
+		return nil, err
+	}
+	sc.loadFavorites()
+
+	var alarms []Alarm
+	for _, sa := range sonosAlarms {
+		alarm := Alarm{
+			ID:      sa.ID,
+			Time:    sa.StartTime,
+			Days:    parseSonosRecurrence(sa.Recurrence),
+			Volume:  sa.Volume,
+			Enabled: sa.Enabled == "1",
+			Title:   sa.ProgramURI,
+		}
+		if len(alarm.Time) > 5 {
+			alarm.Time = alarm.Time[:5]
+		}
+		if strings.HasPrefix(sa.ProgramURI, "x-rincon-buzzer:") {
+			alarm.Title = "Sonos Chime"
+		}
+		for _, fav := range sc.favorites {
+			if fav.URI != "" && fav.URI == sa.ProgramURI {
+				alarm.PresetID = fav.ID
+				alarm.Title = fav.Name
+				break
+			}
+		}
+		alarms = append(alarms, alarm)
+	}
+	return alarms, nil
+}
+
+func (sc *SonosClient) AddAlarm(alarm Alarm) error {
+	if err := sc.loadFavorites(); err != nil {
+		return err
+	}
+
+	var favorite *SonosFavorite
+	for i := range sc.favorites {
+		if sc.favorites[i].ID == alarm.PresetID && sc.favorites[i].URI != "" {
+			favorite = &sc.favorites[i]
+			break
+		}
+	}
+	if favorite == nil {
+		return fmt.Errorf("favorite %d not found", alarm.PresetID)
+	}
+
+	udn, err := sc.getUDN()
+	if err != nil {
+		return err
+	}
+
+	sa := SonosAlarm{
+		StartTime:          alarm.Time + ":00",
+		Duration:           "01:00:00",
+		Recurrence:         sonosRecurrence(alarm.Days),
+		Enabled:            "1",
+		RoomUUID:           udn,
+		ProgramURI:         favorite.URI,
+		ProgramMetaData:    favorite.Meta,
+		PlayMode:           "NORMAL",
+		Volume:             alarm.Volume,
+		IncludeLinkedZones: "0",
+	}
+	if !alarm.Enabled {
+		sa.Enabled = "0"
+	}
+
+	body := fmt.Sprintf(`<u:CreateAlarm xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1">%s</u:CreateAlarm>`, sonosAlarmArgs(sa))
+	_, err = sc.makeAlarmClockRequest("CreateAlarm", body)
+	return err
+}
+
+func (sc *SonosClient) SetAlarmEnabled(id int, enabled bool) error {
+	sa, err := sc.findSonosAlarm(id)
+	if err != nil {
+		return err
+	}
+
+	sa.Enabled = "0"
+	if enabled {
+		sa.Enabled = "1"
+	}
+
+	body := fmt.Sprintf(`<u:UpdateAlarm xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1">
+		<ID>%d</ID>%s
+	</u:UpdateAlarm>`, sa.ID, sonosAlarmArgs(*sa))
+	_, err = sc.makeAlarmClockRequest("UpdateAlarm", body)
+	return err
+}
+
+func (sc *SonosClient) RemoveAlarm(id int) error {
+	if _, err := sc.findSonosAlarm(id); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`<u:DestroyAlarm xmlns:u="urn:schemas-upnp-org:service:AlarmClock:1">
+		<ID>%d</ID>
+	</u:DestroyAlarm>`, id)
+	_, err := sc.makeAlarmClockRequest("DestroyAlarm", body)
+	return err
+}
+
+func (sc *SonosClient) findSonosAlarm(id int) (*SonosAlarm, error) {
+	alarms, err := sc.listSonosAlarms()
+	if err != nil {
+		return nil, err
+	}
+	for _, alarm := range alarms {
+		if alarm.ID == id {
+			return &alarm, nil
+		}
+	}
+	return nil, fmt.Errorf("alarm %d not found", id)
+}
+
+// Arguments shared by CreateAlarm and UpdateAlarm
+func sonosAlarmArgs(sa SonosAlarm) string {
+	return fmt.Sprintf(`
+		<StartLocalTime>%s</StartLocalTime>
+		<Duration>%s</Duration>
+		<Recurrence>%s</Recurrence>
+		<Enabled>%s</Enabled>
+		<RoomUUID>%s</RoomUUID>
+		<ProgramURI>%s</ProgramURI>
+		<ProgramMetaData>%s</ProgramMetaData>
+		<PlayMode>%s</PlayMode>
+		<Volume>%d</Volume>
+		<IncludeLinkedZones>%s</IncludeLinkedZones>`,
+		sa.StartTime, sa.Duration, sa.Recurrence, sa.Enabled, sa.RoomUUID,
+		html.EscapeString(sa.ProgramURI), html.EscapeString(sa.ProgramMetaData),
+		sa.PlayMode, sa.Volume, sa.IncludeLinkedZones)
+}
+
+// Sonos recurrences: ONCE, DAILY, WEEKDAYS, WEEKENDS or ON_<days> with
+// 0 = Sunday, e.g. ON_135
+func sonosRecurrence(days WeekdayMask) string {
+	switch days {
+	case 0:
+		return "ONCE"
+	case everyDay:
+		return "DAILY"
+	case workingDays:
+		return "WEEKDAYS"
+	case weekendDays:
+		return "WEEKENDS"
+	}
+
+	recurrence := "ON_"
+	for day := 0; day < 7; day++ {
+		if days.Has(time.Weekday(day)) {
+			recurrence += strconv.Itoa(day)
+		}
+	}
+	return recurrence
+}
+
+func parseSonosRecurrence(recurrence string) WeekdayMask {
+	switch recurrence {
+	case "DAILY":
+		return everyDay
+	case "WEEKDAYS":
+		return workingDays
+	case "WEEKENDS":
+		return weekendDays
+	}
+
+	var days WeekdayMask
+	if strings.HasPrefix(recurrence, "ON_") {
+		for _, r := range strings.TrimPrefix(recurrence, "ON_") {
+			if r >= '0' && r <= '6' {
+				days |= 1 << uint(r-'0')
+			}
+		}
+	}
+	return days
+}
+
+// Queue management
+func (sc *SonosClient) GetQueue() ([]QueueItem, error) {
+	items, _, err := sc.browseAll("Q:0")
+	if err != nil {
+		return nil, err
+	}
+
+	var queue []QueueItem
+	for i, item := range items {
+		queue = append(queue, QueueItem{
+			Index:  i + 1,
+			Title:  item.Title,
+			Artist: item.Creator,
+			Album:  item.Album,
+			URI:    item.Res,
+		})
+	}
+
+	return queue, nil
+}
+
+func (sc *SonosClient) AddToQueue(uri, title string, next bool) error {
+	resolved, err := resolveStreamURL(uri)
+	if err != nil {
+		return err
+	}
+
+	if title == "" {
+		title = streamTitle(resolved)
+	}
+
+	enqueueURI, metadata := sonosStreamURI(resolved, title)
+	return sc.addURIToQueue(enqueueURI, metadata, next)
+}
+
+func (sc *SonosClient) addURIToQueue(uri, metadata string, next bool) error {
+	asNext := 0
+	if next {
+		asNext = 1
+	}
+
+	body := fmt.Sprintf(`<u:AddURIToQueue xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<EnqueuedURI>%s</EnqueuedURI>
+		<EnqueuedURIMetaData>%s</EnqueuedURIMetaData>
+		<DesiredFirstTrackNumberEnqueued>0</DesiredFirstTrackNumberEnqueued>
+		<EnqueueAsNext>%d</EnqueueAsNext>
+	</u:AddURIToQueue>`, html.EscapeString(uri), html.EscapeString(metadata), asNext)
+
+	_, err := sc.makeSoapRequest("AddURIToQueue", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) RemoveFromQueue(index int) error {
+	body := fmt.Sprintf(`<u:RemoveTrackFromQueue xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<ObjectID>Q:0/%d</ObjectID>
+		<UpdateID>0</UpdateID>
+	</u:RemoveTrackFromQueue>`, index)
+
+	_, err := sc.makeSoapRequest("RemoveTrackFromQueue", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) MoveQueueItem(from, to int) error {
+	// InsertBefore refers to positions before the track is taken out
+	insertBefore := to
+	if to > from {
+		insertBefore = to + 1
+	}
+
+	body := fmt.Sprintf(`<u:ReorderTracksInQueue xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<StartingIndex>%d</StartingIndex>
+		<NumberOfTracks>1</NumberOfTracks>
+		<InsertBefore>%d</InsertBefore>
+		<UpdateID>0</UpdateID>
+	</u:ReorderTracksInQueue>`, from, insertBefore)
+
+	_, err := sc.makeSoapRequest("ReorderTracksInQueue", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) ClearQueue() error {
+	body := `<u:RemoveAllTracksFromQueue xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+	</u:RemoveAllTracksFromQueue>`
+
+	_, err := sc.makeSoapRequest("RemoveAllTracksFromQueue", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) PlayQueueItem(index int) error {
+	// Make sure the queue is the active source before seeking in it
+	udn, err := sc.getUDN()
+	if err != nil {
+		return err
+	}
+	if err := sc.setAVTransportURI(fmt.Sprintf("x-rincon-queue:%s#0", udn), ""); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`<u:Seek xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<Unit>TRACK_NR</Unit>
+		<Target>%d</Target>
+	</u:Seek>`, index)
+
+	if _, err := sc.makeSoapRequest("Seek", "AVTransport", body); err != nil {
+		return err
+	}
+
+	return sc.Play()
+}
+
+func (sc *SonosClient) SaveQueue(name string) error {
+	body := fmt.Sprintf(`<u:SaveQueue xmlns:u="urn:schemas-upnp-org:service:AVTransport:1">
+		<InstanceID>0</InstanceID>
+		<Title>%s</Title>
+		<ObjectID></ObjectID>
+	</u:SaveQueue>`, html.EscapeString(name))
+
+	_, err := sc.makeSoapRequest("SaveQueue", "AVTransport", body)
+	return err
+}
+
+func (sc *SonosClient) AddSlave(slaveIP string) error {
+	// Sonos grouping is more complex - for now, return not implemented
 	return fmt.Errorf("Sonos grouping not yet implemented")
 }
 
@@ -643,6 +1469,126 @@ func (sc *SonosClient) LeaveGroup() error {
 	return fmt.Errorf("Sonos grouping not yet implemented")
 }
 
+// Event subscriptions (GENA)
+const genaSubscriptionTimeout = 30 * time.Minute
+
+func (sc *SonosClient) subscribe(eventPath, callback, sid string) (string, time.Duration, error) {
+	req, err := http.NewRequest("SUBSCRIBE", sc.baseURL+eventPath, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	// Renewals only carry the SID
+	if sid == "" {
+		req.Header.Set("CALLBACK", "<"+callback+">")
+		req.Header.Set("NT", "upnp:event")
+	} else {
+		req.Header.Set("SID", sid)
+	}
+	req.Header.Set("TIMEOUT", fmt.Sprintf("Second-%d", int(genaSubscriptionTimeout/time.Second)))
+
+	resp, err := sc.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("SUBSCRIBE failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("SUBSCRIBE failed with status %d", resp.StatusCode)
+	}
+
+	timeout := genaSubscriptionTimeout
+	if seconds, err := strconv.Atoi(strings.TrimPrefix(resp.Header.Get("TIMEOUT"), "Second-")); err == nil && seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if newSID := resp.Header.Get("SID"); newSID != "" {
+		sid = newSID
+	}
+	return sid, timeout, nil
+}
+
+func (sc *SonosClient) unsubscribe(eventPath, sid string) {
+	req, err := http.NewRequest("UNSUBSCRIBE", sc.baseURL+eventPath, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("SID", sid)
+	if resp, err := sc.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// Push status and group changes from GENA events, or poll when the app
+// isn't running an event listener
+func (sc *SonosClient) Watch(stop <-chan struct{}, onStatus func(*Status), onTopology func()) {
+	parsed, err := url.Parse(sc.baseURL)
+	if genaEvents == nil || err != nil {
+		pollStatus(sc, stop, onStatus)
+		return
+	}
+	callbackBase, err := genaEvents.callbackBase(parsed.Hostname())
+	if err != nil {
+		pollStatus(sc, stop, onStatus)
+		return
+	}
+
+	refreshStatus := func() {
+		if status, err := sc.GetStatus(); err == nil {
+			onStatus(status)
+		}
+	}
+	subscriptions := []struct {
+		path     string
+		handler  func()
+		callback string
+		sid      string
+	}{
+		{path: "/MediaRenderer/AVTransport/Event", handler: refreshStatus},
+		{path: "/MediaRenderer/RenderingControl/Event", handler: refreshStatus},
+		{path: "/ZoneGroupTopology/Event", handler: onTopology},
+	}
+	// Handlers are in place before the first SUBSCRIBE, so no NOTIFY is lost
+	for i := range subscriptions {
+		sub := &subscriptions[i]
+		callbackPath := genaEvents.handle(sub.handler)
+		defer genaEvents.remove(callbackPath)
+		sub.callback = callbackBase + callbackPath
+	}
+	defer func() {
+		for _, sub := range subscriptions {
+			if sub.sid != "" {
+				sc.unsubscribe(sub.path, sub.sid)
+			}
+		}
+	}()
+
+	for {
+		// Subscribe, or renew halfway through the subscription timeout
+		wait := genaSubscriptionTimeout / 2
+		for i := range subscriptions {
+			sub := &subscriptions[i]
+			sid, timeout, err := sc.subscribe(sub.path, sub.callback, sub.sid)
+			if err != nil && sub.sid != "" {
+				// Expired (e.g. after a player restart): start over
+				sub.sid = ""
+				sid, timeout, err = sc.subscribe(sub.path, sub.callback, "")
+			}
+			if err != nil {
+				wait = 30 * time.Second
+				continue
+			}
+			sub.sid = sid
+			if timeout/2 < wait {
+				wait = timeout / 2
+			}
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-stop:
+			return
+		}
+	}
+}
+
 func (sc *SonosClient) GetDeviceType() DeviceType {
 	return DeviceTypeSonos
 }
@@ -680,9 +1626,9 @@ func (sc *SonosClient) DebugAPI() string {
 	}
 
 	// Add favorite discovery debug info
-	sc.favorites = nil // Clear cache to force reload
+	sc.RefreshPresets()
 	sc.loadFavorites()
-	results = append(results, fmt.Sprintf("Radio Favorites: %d found", len(sc.favorites)))
+	results = append(results, fmt.Sprintf("Favorites: %d found", len(sc.favorites)))
 
 	return fmt.Sprintf("Sonos Debug: %s", strings.Join(results, " | "))
 }
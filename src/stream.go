@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Playlist formats that station sites commonly publish instead of the stream itself
+var playlistExtensions = map[string]bool{
+	".pls":  true,
+	".m3u":  true,
+	".m3u8": true,
+}
+
+// Extensions of plain audio files (everything else is treated as a radio stream)
+var audioFileExtensions = map[string]bool{
+	".mp3":  true,
+	".flac": true,
+	".aac":  true,
+	".m4a":  true,
+	".ogg":  true,
+	".wav":  true,
+	".wma":  true,
+	".aif":  true,
+	".aiff": true,
+}
+
+func urlExtension(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(path.Ext(u.Path))
+}
+
+func isPlaylistURL(rawURL string) bool {
+	return playlistExtensions[urlExtension(rawURL)]
+}
+
+func isAudioFileURL(rawURL string) bool {
+	return audioFileExtensions[urlExtension(rawURL)]
+}
+
+// Resolve a PLS/M3U playlist URL to the first stream it references.
+// URLs that don't look like playlists are returned unchanged.
+func resolveStreamURL(rawURL string) (string, error) {
+	if !isPlaylistURL(rawURL) {
+		return rawURL, nil
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch playlist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("playlist returned status %d", resp.StatusCode)
+	}
+
+	// Playlists are tiny; don't read forever if the URL is actually a stream
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	content := string(data)
+
+	// HLS playlists reference segments, not streams - the player handles them itself
+	if strings.Contains(content, "#EXT-X-") {
+		return rawURL, nil
+	}
+
+	var entries []Preset
+	if urlExtension(rawURL) == ".pls" || strings.Contains(strings.ToLower(content), "[playlist]") {
+		entries = parsePLS(content)
+	} else {
+		entries = parseM3U(content)
+	}
+
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no stream found in playlist")
+	}
+
+	return resolvePlaylistEntry(rawURL, entries[0].URL), nil
+}
+
+// Playlist entries may be relative to the playlist's own URL
+func resolvePlaylistEntry(playlistURL, entry string) string {
+	base, err := url.Parse(playlistURL)
+	if err != nil {
+		return entry
+	}
+	ref, err := url.Parse(entry)
+	if err != nil {
+		return entry
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// Parse the FileN=/TitleN= entries of a PLS playlist, ordered by N
+func parsePLS(content string) []Preset {
+	byNumber := make(map[int]*Preset)
+	var numbers []int
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		idx := strings.Index(line, "=")
+		if idx == -1 {
+			continue
+		}
+		key, value := strings.ToLower(line[:idx]), strings.TrimSpace(line[idx+1:])
+
+		var field string
+		switch {
+		case strings.HasPrefix(key, "file"):
+			field = "file"
+		case strings.HasPrefix(key, "title"):
+			field = "title"
+		default:
+			continue
+		}
+
+		n, err := strconv.Atoi(key[len(field):])
+		if err != nil {
+			continue
+		}
+		entry, ok := byNumber[n]
+		if !ok {
+			entry = &Preset{}
+			byNumber[n] = entry
+			numbers = append(numbers, n)
+		}
+		if field == "file" {
+			entry.URL = value
+		} else {
+			entry.Name = value
+		}
+	}
+
+	sort.Ints(numbers)
+	var entries []Preset
+	for _, n := range numbers {
+		if entry := byNumber[n]; entry.URL != "" {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries
+}
+
+// Parse an M3U playlist in order, taking names from #EXTINF lines
+func parseM3U(content string) []Preset {
+	var entries []Preset
+	var name string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if strings.HasPrefix(line, "#EXTINF:") {
+			name = extinfTitle(line)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, Preset{Name: name, URL: line})
+		name = ""
+	}
+	return entries
+}
+
+// The title follows the first comma outside of quoted attributes
+func extinfTitle(line string) string {
+	inQuotes := false
+	for i, r := range line {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				return strings.TrimSpace(line[i+1:])
+			}
+		}
+	}
+	return ""
+}
+
+// Fallback title for a stream when the user doesn't give one
+func streamTitle(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
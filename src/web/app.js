@@ -0,0 +1,253 @@
+// Web UI for the serve mode. Everything goes through the JSON API; live
+// changes arrive over the /api/events WebSocket.
+
+const state = {
+  texts: {},
+  players: [],
+  statuses: {},
+  selected: null,
+  // Re-rendering while a slider or card is dragged would interrupt it
+  interacting: false,
+  pending: false,
+};
+
+function t(key) {
+  return state.texts[key] || key;
+}
+
+async function api(method, path, body) {
+  const options = { method };
+  if (method !== "GET") {
+    // The server only accepts JSON requests, even without a body
+    options.headers = { "Content-Type": "application/json" };
+  }
+  if (body !== undefined) {
+    options.body = JSON.stringify(body);
+  }
+  const response = await fetch(path, options);
+  const data = await response.json();
+  if (!response.ok) {
+    throw new Error(data.error || data.result || response.statusText);
+  }
+  return data;
+}
+
+function playerPath(player, action) {
+  return "/api/players/" + encodeURIComponent(player.ip) + "/" + action;
+}
+
+function showMessage(text) {
+  document.getElementById("connection").textContent = text;
+}
+
+async function control(player, action, body) {
+  try {
+    const status = await api("POST", playerPath(player, action), body);
+    state.statuses[player.ip] = status;
+    render();
+  } catch (err) {
+    showMessage("❌ " + player.name + ": " + err.message);
+  }
+}
+
+async function loadTexts() {
+  const lang = new URLSearchParams(location.search).get("lang") || "";
+  state.texts = await api("GET", "/api/i18n?lang=" + encodeURIComponent(lang));
+  document.documentElement.lang = state.texts.lang;
+  document.title = t("web_title");
+  for (const el of document.querySelectorAll("[data-text]")) {
+    el.textContent = t(el.dataset.text);
+  }
+}
+
+async function loadPlayers() {
+  state.players = await api("GET", "/api/players");
+  await Promise.all(state.players.map(async (player) => {
+    try {
+      state.statuses[player.ip] = await api("GET", playerPath(player, "status"));
+    } catch (err) {
+      delete state.statuses[player.ip];
+    }
+  }));
+  render();
+}
+
+// BluOS reports artwork relative to the player
+function artworkURL(player, status) {
+  if (!status || !status.image) {
+    return "";
+  }
+  if (/^https?:\/\//.test(status.image)) {
+    return status.image;
+  }
+  return "http://" + player.ip + ":11000" + status.image;
+}
+
+function stateText(status) {
+  if (!status) {
+    return t("web_offline");
+  }
+  const s = status.state.toLowerCase();
+  if (s.startsWith("play") || s === "stream") {
+    return t("web_state_playing");
+  }
+  if (s.startsWith("pause")) {
+    return t("web_state_paused");
+  }
+  return t("web_state_stopped");
+}
+
+function render() {
+  if (state.interacting) {
+    state.pending = true;
+    return;
+  }
+  state.pending = false;
+
+  const container = document.getElementById("players");
+  const template = document.getElementById("player-template");
+  container.replaceChildren();
+
+  if (state.players.length === 0) {
+    container.textContent = t("no_players");
+    return;
+  }
+
+  for (const player of state.players) {
+    const status = state.statuses[player.ip];
+    const card = template.content.firstElementChild.cloneNode(true);
+    card.dataset.ip = player.ip;
+    card.classList.toggle("selected", state.selected === player.ip);
+
+    card.querySelector(".name").textContent = player.name;
+    const track = status ? [status.song, status.artist].filter(Boolean).join(" – ") : "";
+    card.querySelector(".track").textContent = track || t("web_nothing_playing");
+    card.querySelector(".state").textContent = stateText(status);
+
+    const artwork = card.querySelector(".artwork");
+    const src = artworkURL(player, status);
+    if (src) {
+      artwork.src = src;
+    } else {
+      artwork.removeAttribute("src");
+    }
+
+    for (const button of card.querySelectorAll("button[data-action]")) {
+      button.title = t(button.dataset.title);
+      button.addEventListener("click", (event) => {
+        event.stopPropagation();
+        control(player, button.dataset.action, {});
+      });
+    }
+
+    const slider = card.querySelector(".volume input");
+    const label = card.querySelector(".volume span");
+    slider.title = t("web_volume");
+    slider.value = status ? status.volume : 0;
+    label.textContent = slider.value + "%";
+    slider.addEventListener("click", (event) => event.stopPropagation());
+    slider.addEventListener("input", () => {
+      state.interacting = true;
+      label.textContent = slider.value + "%";
+    });
+    slider.addEventListener("change", () => {
+      endInteraction();
+      control(player, "volume", { level: Number(slider.value) });
+    });
+
+    card.addEventListener("click", () => selectPlayer(player));
+    addDragAndDrop(card, player);
+    container.appendChild(card);
+  }
+}
+
+function endInteraction() {
+  state.interacting = false;
+  if (state.pending) {
+    render();
+  }
+}
+
+// Drop a player onto another to group them; the target becomes the master
+function addDragAndDrop(card, player) {
+  card.addEventListener("dragstart", (event) => {
+    state.interacting = true;
+    event.dataTransfer.setData("text/plain", player.ip);
+  });
+  card.addEventListener("dragend", endInteraction);
+  card.addEventListener("dragover", (event) => {
+    event.preventDefault();
+    card.classList.add("drop-target");
+  });
+  card.addEventListener("dragleave", () => card.classList.remove("drop-target"));
+  card.addEventListener("drop", (event) => {
+    event.preventDefault();
+    card.classList.remove("drop-target");
+    const slave = event.dataTransfer.getData("text/plain");
+    if (slave && slave !== player.ip) {
+      control(player, "group", { slaves: [slave] });
+    }
+  });
+}
+
+async function selectPlayer(player) {
+  state.selected = player.ip;
+  render();
+
+  const panel = document.getElementById("presets-panel");
+  const list = document.getElementById("presets");
+  document.getElementById("presets-player").textContent = "– " + player.name;
+  panel.hidden = false;
+  list.textContent = t("web_loading");
+
+  try {
+    const presets = await api("GET", playerPath(player, "presets"));
+    list.replaceChildren();
+    if (!presets || presets.length === 0) {
+      list.textContent = t("web_no_presets");
+      return;
+    }
+    for (const preset of presets) {
+      const item = document.createElement("li");
+      item.textContent = preset.id + ". " + preset.name;
+      item.addEventListener("click", () => control(player, "play", { preset: preset.id }));
+      list.appendChild(item);
+    }
+  } catch (err) {
+    list.textContent = "❌ " + err.message;
+  }
+}
+
+function connectEvents() {
+  const protocol = location.protocol === "https:" ? "wss:" : "ws:";
+  const socket = new WebSocket(protocol + "//" + location.host + "/api/events");
+
+  socket.onopen = () => showMessage(t("web_connected"));
+  socket.onmessage = (message) => {
+    const event = JSON.parse(message.data);
+    if (event.type === "players" || event.type === "topology") {
+      loadPlayers();
+      return;
+    }
+    if (event.status) {
+      state.statuses[event.ip] = event.status;
+      render();
+    }
+  };
+  socket.onclose = () => {
+    showMessage(t("web_disconnected"));
+    setTimeout(connectEvents, 5000);
+  };
+}
+
+async function start() {
+  try {
+    await loadTexts();
+    await loadPlayers();
+  } catch (err) {
+    showMessage("❌ " + err.message);
+  }
+  connectEvents();
+}
+
+start();
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Minimal RFC 6455 server side: text messages out, control frames in
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xA
+)
+
+// Largest client message accepted; clients only send control frames
+const wsMaxPayload = 64 * 1024
+
+type wsConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		return nil, fmt.Errorf("not a WebSocket request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, fmt.Errorf("unsupported WebSocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, fmt.Errorf("missing Sec-WebSocket-Key")
+	}
+	if !sameOrigin(r) {
+		return nil, fmt.Errorf("cross-origin WebSocket request from %s", r.Header.Get("Origin"))
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, fmt.Errorf("connection cannot be upgraded")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+// Browsers send the Origin of the page that opens a WebSocket, and WebSockets
+// aren't subject to CORS, so pages of other sites must be turned away here.
+// Clients other than browsers usually send no Origin.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// Server frames are never masked
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	if _, err := c.conn.Write(header); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(payload)
+	return err
+}
+
+// Read the next data message, answering pings and closes on the way.
+// Returns io.EOF once the client closed the connection.
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(c.reader, head[:]); err != nil {
+			return nil, err
+		}
+		opcode := head[0] & 0x0F
+		masked := head[1]&0x80 != 0
+		length := uint64(head[1] & 0x7F)
+
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+				return nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+				return nil, err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		if length > wsMaxPayload {
+			return nil, fmt.Errorf("WebSocket message too large")
+		}
+
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+				return nil, err
+			}
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.reader, payload); err != nil {
+			return nil, err
+		}
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch opcode {
+		case wsOpClose:
+			c.writeFrame(wsOpClose, nil)
+			return nil, io.EOF
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+		case wsOpPong:
+		default:
+			return payload, nil
+		}
+	}
+}
+
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
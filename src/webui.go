@@ -0,0 +1,56 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// Single-page web UI served by "serve" at /. It only talks to the JSON API
+// and /api/events, and takes its texts from /api/i18n.
+//
+//go:embed web
+var webFiles embed.FS
+
+func webUIHandler() http.Handler {
+	root, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServer(http.FS(root))
+}
+
+// GET /api/i18n?lang=de: the localization catalog of one language with
+// English fallbacks. Without lang the browser's Accept-Language decides.
+func handleI18n(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "use GET")
+		return
+	}
+
+	lang := requestLanguage(r)
+	catalog := make(map[string]string, len(texts[LangEnglish]))
+	for key, text := range texts[LangEnglish] {
+		catalog[key] = text
+	}
+	for key, text := range texts[lang] {
+		catalog[key] = text
+	}
+	catalog["lang"] = string(lang)
+	writeJSON(w, http.StatusOK, catalog)
+}
+
+func requestLanguage(r *http.Request) Language {
+	if lang := Language(strings.ToLower(r.URL.Query().Get("lang"))); texts[lang] != nil {
+		return lang
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := Language(strings.ToLower(strings.SplitN(tag, "-", 2)[0]))
+		if texts[lang] != nil {
+			return lang
+		}
+	}
+	return currentLanguage
+}